@@ -0,0 +1,246 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mirage20/ccstatus-go/internal/cache"
+	"github.com/mirage20/ccstatus-go/internal/cache/bolt"
+	"github.com/mirage20/ccstatus-go/internal/cache/file"
+	"github.com/mirage20/ccstatus-go/internal/config"
+)
+
+// runCache handles the "ccstatus cache" subcommand.
+func runCache(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		showCacheHelp(out)
+		return nil
+	}
+
+	// Use the cache directory configured for the current directory's project
+	projectDir, _ := os.Getwd()
+	cfgReader := config.NewReader(projectDir)
+	dir := config.Get(cfgReader, "cache.dir", os.TempDir())
+
+	if cache.Backend(cfgReader) != cache.BackendFile {
+		return fmt.Errorf("cache commands only support the file backend; remove %s to clear the bolt cache",
+			filepath.Join(dir, bolt.FileName))
+	}
+
+	switch args[0] {
+	case "list":
+		return cacheList(dir, out)
+	case "show":
+		if len(args) != 2 { //nolint:mnd // subcommand plus session name
+			return errors.New("usage: ccstatus cache show <session>")
+		}
+		return cacheShow(dir, args[1], out)
+	case "clear":
+		return cacheClear(dir, args[1:], out)
+	case "stats":
+		return cacheStats(dir, out)
+	case "help", "-h", "--help":
+		showCacheHelp(out)
+		return nil
+	default:
+		return fmt.Errorf("unknown cache command %q (see 'ccstatus cache help')", args[0])
+	}
+}
+
+// cacheList prints one line per cache file.
+func cacheList(dir string, out io.Writer) error {
+	files, err := file.List(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		fmt.Fprintf(out, "No cache files in %s\n", dir)
+		return nil
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0) //nolint:mnd // column padding
+	fmt.Fprintln(w, "SESSION\tENTRIES\tEXPIRED\tSIZE\tMODIFIED")
+	for _, f := range files {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n",
+			f.Name, len(f.Entries), countExpired(f.Entries, now), formatBytes(f.Size), formatAge(now, f.ModTime))
+	}
+	return w.Flush()
+}
+
+// cacheShow prints the entries of one cache file.
+func cacheShow(dir, name string, out io.Writer) error {
+	f, err := file.Inspect(dir, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("no cache file for session %q in %s", name, dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	fmt.Fprintf(out, "Session:  %s\n", f.Name)
+	fmt.Fprintf(out, "File:     %s (%s)\n", f.Path, formatBytes(f.Size))
+	fmt.Fprintf(out, "Updated:  %s\n", formatTime(f.LastUpdated))
+	fmt.Fprintf(out, "Version:  %s\n", f.Version)
+
+	for _, e := range f.Entries {
+		expiry := "expires in " + e.ExpiresAt.Sub(now).Round(time.Second).String()
+		if e.Expired(now) {
+			expiry = "expired " + formatAge(now, e.ExpiresAt)
+		}
+
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "[%s] %s, cached %s, %s\n", e.Key, formatBytes(int64(len(e.Data))), formatAge(now, e.CachedAt), expiry)
+
+		var pretty any
+		if json.Unmarshal(e.Data, &pretty) == nil {
+			enc := json.NewEncoder(out)
+			enc.SetIndent("", "  ")
+			_ = enc.Encode(pretty)
+		}
+	}
+	return nil
+}
+
+// cacheClear removes cache files: one session, all files, or files older than a duration.
+func cacheClear(dir string, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("cache clear", flag.ContinueOnError)
+	flags.SetOutput(out)
+	all := flags.Bool("all", false, "remove all cache files")
+	olderThan := flags.Duration("older-than", 0, "remove cache files not modified within this duration (e.g. 24h)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// A single session by name
+	if flags.NArg() == 1 && !*all && *olderThan == 0 {
+		if err := file.Remove(dir, flags.Arg(0)); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("no cache file for session %q in %s", flags.Arg(0), dir)
+			}
+			return err
+		}
+		fmt.Fprintf(out, "Removed cache for session %s\n", flags.Arg(0))
+		return nil
+	}
+	if flags.NArg() != 0 || *all == (*olderThan > 0) {
+		return errors.New("usage: ccstatus cache clear <session> | --all | --older-than <duration>")
+	}
+
+	files, err := file.List(dir)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-*olderThan)
+	removed := 0
+	for _, f := range files {
+		if !*all && f.ModTime.After(cutoff) {
+			continue
+		}
+		if err = file.Remove(dir, f.Name); err == nil {
+			removed++
+		}
+	}
+	fmt.Fprintf(out, "Removed %d cache file(s) from %s\n", removed, dir)
+	return nil
+}
+
+// cacheStats prints totals across all cache files.
+func cacheStats(dir string, out io.Writer) error {
+	files, err := file.List(dir)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var entries, expired int
+	var size int64
+	var oldest, newest time.Time
+	for _, f := range files {
+		entries += len(f.Entries)
+		expired += countExpired(f.Entries, now)
+		size += f.Size
+		if oldest.IsZero() || f.ModTime.Before(oldest) {
+			oldest = f.ModTime
+		}
+		if f.ModTime.After(newest) {
+			newest = f.ModTime
+		}
+	}
+
+	fmt.Fprintf(out, "Directory: %s\n", dir)
+	fmt.Fprintf(out, "Files:     %d\n", len(files))
+	fmt.Fprintf(out, "Entries:   %d (%d expired)\n", entries, expired)
+	fmt.Fprintf(out, "Size:      %s\n", formatBytes(size))
+	if len(files) > 0 {
+		fmt.Fprintf(out, "Oldest:    %s\n", formatAge(now, oldest))
+		fmt.Fprintf(out, "Newest:    %s\n", formatAge(now, newest))
+	}
+	return nil
+}
+
+// countExpired returns the number of expired entries.
+func countExpired(entries []file.EntryInfo, now time.Time) int {
+	count := 0
+	for _, e := range entries {
+		if e.Expired(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// formatBytes formats a size like "512 B" or "1.5 KiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, suffix := float64(n)/unit, "KiB"
+	for _, next := range []string{"MiB", "GiB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
+
+// formatAge formats how long ago t was, like "3m ago".
+func formatAge(now, t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	return now.Sub(t).Round(time.Second).String() + " ago"
+}
+
+// formatTime formats t in local time, or "unknown" if unset.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func showCacheHelp(out io.Writer) {
+	fmt.Fprintln(out, "Usage:")
+	fmt.Fprintln(out, "  ccstatus cache list                       List cache files")
+	fmt.Fprintln(out, "  ccstatus cache show <session>             Show entries of a cache file")
+	fmt.Fprintln(out, "  ccstatus cache clear <session>            Remove a session's cache file")
+	fmt.Fprintln(out, "  ccstatus cache clear --all                Remove all cache files")
+	fmt.Fprintln(out, "  ccstatus cache clear --older-than <dur>   Remove cache files older than a duration (e.g. 24h)")
+	fmt.Fprintln(out, "  ccstatus cache stats                      Show totals across cache files")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "<session> is a session ID, \"global\", or a workspace cache name (ws-...) as shown by list.")
+	fmt.Fprintln(out, "The cache directory is read from the config for the current directory.")
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/mirage20/ccstatus-go/internal/cache"
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/daemon"
+)
+
+// Environment variables controlling daemon use.
+const (
+	socketEnv   = "CCSTATUS_SOCKET"    // Overrides the daemon socket path
+	noDaemonEnv = "CCSTATUS_NO_DAEMON" // Set to render in-process without trying the daemon
+)
+
+const (
+	// Default time after which an unused session's caches, and an unused project's
+	// renderer and shared caches, are flushed and dropped.
+	defaultSessionIdle = 30 * time.Minute
+
+	// How often idle sessions are evicted.
+	evictInterval = time.Minute
+)
+
+// socketPath returns the daemon socket path from the environment or the per-user default.
+func socketPath() string {
+	if path := os.Getenv(socketEnv); path != "" {
+		return path
+	}
+	return daemon.DefaultSocketPath()
+}
+
+// runDaemon handles the "ccstatus daemon" subcommand.
+func runDaemon(args []string) error {
+	flags := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	path := flags.String("socket", socketPath(), "Unix socket to listen on (also read from $"+socketEnv+")")
+	sessionIdle := flags.Duration("session-idle", defaultSessionIdle, "drop cached data of sessions unused for this long")
+	exitIdle := flags.Duration("exit-idle", 0, "exit after receiving no requests for this long (0 = never)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	listener, err := daemon.Listen(*path)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(*path)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	d := newDaemonState()
+	defer d.close()
+
+	go d.evictLoop(ctx, stop, *sessionIdle, *exitIdle)
+
+	fmt.Fprintf(os.Stderr, "ccstatus daemon listening on %s\n", *path)
+	return daemon.Serve(ctx, listener, d.handle)
+}
+
+// daemonState keeps renderers per project and cache stores per session warm between requests.
+// Workspace and global caches are shared by all sessions, so one session's entries
+// are hits for the others.
+type daemonState struct {
+	mu          sync.Mutex
+	renderers   map[string]*daemonRenderer // By project directory
+	sessions    map[string]*daemonSession  // By session ID
+	caches      *cache.Shared
+	lastRequest time.Time
+}
+
+// daemonRenderer holds a project's renderer with its last use, for eviction.
+type daemonRenderer struct {
+	renderer *renderer
+	lastUsed time.Time
+}
+
+// daemonSession holds a session's cache store and the config it was created with.
+type daemonSession struct {
+	store     *cache.Store
+	cfgReader *config.Reader
+	lastUsed  time.Time
+	mu        sync.Mutex // Serializes renders of the same session
+}
+
+func newDaemonState() *daemonState {
+	return &daemonState{
+		renderers:   make(map[string]*daemonRenderer),
+		sessions:    make(map[string]*daemonSession),
+		caches:      cache.NewShared(),
+		lastRequest: time.Now(),
+	}
+}
+
+// handle renders one request.
+func (d *daemonState) handle(ctx context.Context, request []byte) (string, error) {
+	claudeSession, err := readClaudeSession(bytes.NewReader(request))
+	if err != nil {
+		return "", err
+	}
+
+	r, sess := d.acquire(claudeSession)
+
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	queue := &core.PrefetchQueue{}
+	output := r.render(core.WithPrefetchQueue(ctx, queue), claudeSession, sess.store)
+
+	// Persist cache changes so in-process invocations and restarts see them
+	_ = sess.store.Flush()
+
+	// Refresh entries about to expire after responding
+	if names := queue.Names(); len(names) > 0 {
+		go func() {
+			sess.mu.Lock()
+			defer sess.mu.Unlock()
+
+			prefetch(context.WithoutCancel(ctx), claudeSession, r.cfgReader, sess.store, names)
+			_ = sess.store.Flush()
+		}()
+	}
+
+	return output, nil
+}
+
+// acquire returns the renderer and session state for a request, reloading them if the config changed.
+func (d *daemonState) acquire(claudeSession *core.ClaudeSession) (*renderer, *daemonSession) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	d.lastRequest = now
+
+	projectDir := claudeSession.Workspace.ProjectDir
+	project, exists := d.renderers[projectDir]
+	if !exists || project.renderer.cfgReader.Changed() {
+		project = &daemonRenderer{renderer: newRenderer(config.NewReader(projectDir))}
+		d.renderers[projectDir] = project
+	}
+	project.lastUsed = now
+	r := project.renderer
+
+	sess, exists := d.sessions[claudeSession.SessionID]
+	if !exists || sess.cfgReader != r.cfgReader {
+		if exists {
+			_ = sess.store.Close()
+		}
+		sess = &daemonSession{
+			store:     cache.New(r.cfgReader, claudeSession.SessionID),
+			cfgReader: r.cfgReader,
+		}
+		sess.store.ShareWith(d.caches)
+		d.sessions[claudeSession.SessionID] = sess
+	}
+	sess.lastUsed = now
+
+	return r, sess
+}
+
+// evictLoop drops idle sessions, renderers and shared caches, and stops the daemon
+// after exitIdle without requests.
+func (d *daemonState) evictLoop(ctx context.Context, stop func(), sessionIdle, exitIdle time.Duration) {
+	ticker := time.NewTicker(evictInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if idle := d.evict(now, sessionIdle); exitIdle > 0 && idle > exitIdle {
+				stop()
+				return
+			}
+		}
+	}
+}
+
+// evict drops sessions and renderers unused for sessionIdle at now, then the shared
+// caches no session asked for meanwhile. Returns the time since the last request.
+func (d *daemonState) evict(now time.Time, sessionIdle time.Duration) time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for id, sess := range d.sessions {
+		if now.Sub(sess.lastUsed) > sessionIdle {
+			_ = sess.store.Close()
+			delete(d.sessions, id)
+		}
+	}
+	for projectDir, project := range d.renderers {
+		if now.Sub(project.lastUsed) > sessionIdle {
+			delete(d.renderers, projectDir)
+		}
+	}
+	d.caches.Evict(now, sessionIdle)
+
+	return now.Sub(d.lastRequest)
+}
+
+// close flushes all session and shared caches.
+func (d *daemonState) close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, sess := range d.sessions {
+		_ = sess.store.Close()
+	}
+	_ = d.caches.Close()
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mirage20/ccstatus-go/internal/core"
+)
+
+func TestDaemonState_SharesCachesAndEvicts(t *testing.T) {
+	project := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(project, ".claude"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "cache:\n  dir: " + t.TempDir() + "\n"
+	if err := os.WriteFile(filepath.Join(project, ".claude", "ccstatus.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	d := newDaemonState()
+	defer d.close()
+
+	newSession := func(id string) *core.ClaudeSession {
+		session := &core.ClaudeSession{SessionID: id}
+		session.Workspace.ProjectDir = project
+		return session
+	}
+	firstRenderer, first := d.acquire(newSession("session-1"))
+	secondRenderer, second := d.acquire(newSession("session-2"))
+
+	if firstRenderer != secondRenderer {
+		t.Error("expected sessions of a project to share its renderer")
+	}
+	if first.store.ForScope(core.CacheScopeWorkspace, "/repo") != second.store.ForScope(core.CacheScopeWorkspace, "/repo") {
+		t.Error("expected sessions to share workspace caches")
+	}
+	if first.store.ForScope(core.CacheScopeSession, "") == second.store.ForScope(core.CacheScopeSession, "") {
+		t.Error("expected separate session caches")
+	}
+
+	d.evict(time.Now().Add(time.Hour), defaultSessionIdle)
+	if len(d.sessions) != 0 || len(d.renderers) != 0 {
+		t.Errorf("expected idle sessions and renderers evicted, got %d and %d", len(d.sessions), len(d.renderers))
+	}
+}
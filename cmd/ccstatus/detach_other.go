@@ -0,0 +1,8 @@
+//go:build !unix
+
+package main
+
+import "os/exec"
+
+// detach is a no-op where process sessions aren't available; the worker still outlives us.
+func detach(*exec.Cmd) {}
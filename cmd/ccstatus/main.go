@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -12,9 +13,11 @@ import (
 	"github.com/mirage20/ccstatus-go/internal/cache"
 	"github.com/mirage20/ccstatus-go/internal/config"
 	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/daemon"
 
 	// Import providers for self-registration.
 	_ "github.com/mirage20/ccstatus-go/internal/providers/git"
+	_ "github.com/mirage20/ccstatus-go/internal/providers/history"
 	_ "github.com/mirage20/ccstatus-go/internal/providers/sessioninfo"
 
 	// Import components for self-registration.
@@ -25,9 +28,16 @@ import (
 	_ "github.com/mirage20/ccstatus-go/internal/components/claudecode/model"
 	_ "github.com/mirage20/ccstatus-go/internal/components/claudecode/version"
 	_ "github.com/mirage20/ccstatus-go/internal/components/git/branch"
+	_ "github.com/mirage20/ccstatus-go/internal/components/git/commit"
+	_ "github.com/mirage20/ccstatus-go/internal/components/git/diff"
+	_ "github.com/mirage20/ccstatus-go/internal/components/git/remote"
 	_ "github.com/mirage20/ccstatus-go/internal/components/git/stash"
+	_ "github.com/mirage20/ccstatus-go/internal/components/git/state"
 	_ "github.com/mirage20/ccstatus-go/internal/components/git/status"
+	_ "github.com/mirage20/ccstatus-go/internal/components/git/submodules"
 	_ "github.com/mirage20/ccstatus-go/internal/components/git/sync"
+	_ "github.com/mirage20/ccstatus-go/internal/components/git/tag"
+	_ "github.com/mirage20/ccstatus-go/internal/components/git/worktree"
 	_ "github.com/mirage20/ccstatus-go/internal/components/layout/newline"
 	_ "github.com/mirage20/ccstatus-go/internal/components/ratelimit/fivehour"
 	_ "github.com/mirage20/ccstatus-go/internal/components/ratelimit/sevenday"
@@ -51,6 +61,22 @@ func main() {
 		case "version", "-v", "--version":
 			showVersion()
 			return
+		case "daemon":
+			if err := runDaemon(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "prefetch":
+			// Internal: background refresh worker started by spawnPrefetch
+			_ = runPrefetch(os.Args[2:])
+			return
+		case "cache":
+			if err := runCache(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
 		}
 	}
 
@@ -64,8 +90,21 @@ func main() {
 func run() error {
 	ctx := context.Background()
 
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+
+	// Let a running daemon render with its warm config and caches
+	if os.Getenv(noDaemonEnv) == "" {
+		if output, forwardErr := daemon.Forward(socketPath(), input); forwardErr == nil {
+			fmt.Fprintln(os.Stdout, output)
+			return nil
+		}
+	}
+
 	// Read Claude session information from stdin (NOT a provider!)
-	claudeSession, err := readClaudeSession(os.Stdin)
+	claudeSession, err := readClaudeSession(bytes.NewReader(input))
 	if err != nil {
 		// If no valid input, show help
 		showHelp()
@@ -75,69 +114,23 @@ func run() error {
 	// Load configuration with project directory from Claude session
 	cfgReader := config.NewReader(claudeSession.Workspace.ProjectDir)
 
-	// Create cache with session isolation using the new factory
+	// Create cache store; providers pick the session, workspace or global cache
 	c := cache.New(cfgReader, claudeSession.SessionID)
-	defer c.Close() // Ignore errors - don't pollute status line output
-
-	// Create status line with configuration
-	statusLine := core.NewStatusLine(cfgReader)
-
-	// STEP 1: Get active components from config or use defaults
-	componentNames := config.Get(cfgReader, "active", []string{})
-	if len(componentNames) == 0 {
-		// Default component order if no active list is configured
-		componentNames = []string{
-			"model",
-			"context",
-			"ratelimit.fivehour",
-			"ratelimit.sevenday",
-			"changes",
-			"duration",
-			"version",
-			"newline",
-			"cwd",
-			"git.branch",
-			"git.status",
-			"git.sync",
-			"git.stash",
-		}
-	}
-
-	// Create components and collect their provider requirements
-	var components []core.Component
-	providerSet := make(map[string]bool)
-
-	for _, name := range componentNames {
-		if comp, exists := core.CreateComponent(name, cfgReader); exists {
-			components = append(components, comp)
 
-			// Collect provider dependencies
-			for _, providerName := range comp.RequiredProviders() {
-				providerSet[providerName] = true
-			}
-		}
-	}
+	// Render and output the status line, noting cache entries close to expiry
+	queue := &core.PrefetchQueue{}
+	output := newRenderer(cfgReader).render(core.WithPrefetchQueue(ctx, queue), claudeSession, c)
+	fmt.Fprintln(os.Stdout, output)
 
-	// STEP 2: Create only the providers that components need
-	for providerName := range providerSet {
-		// Create provider from registry (registry handles caching)
-		if provider, exists := core.CreateProvider(providerName, cfgReader, claudeSession, c); exists {
-			statusLine.AddProvider(provider)
-		} else {
-			// Log warning that a required provider is not registered
-			fmt.Fprintf(os.Stderr, "Warning: Component requires provider '%s' but it's not registered\n", providerName)
-		}
-	}
+	// Save before the worker loads the cache
+	_ = c.Close() // Ignore errors - don't pollute status line output
 
-	// STEP 3: Add components to statusline
-	for _, comp := range components {
-		statusLine.AddComponent(comp)
+	// Refresh entries about to expire so the next invocation is a cache hit,
+	// unless other processes are refreshing them already
+	if names := idlePrefetches(claudeSession, cfgReader, c, queue.Names()); len(names) > 0 {
+		_ = spawnPrefetch(input, names)
 	}
 
-	// Render and output the status line
-	output := statusLine.Render(ctx)
-	fmt.Fprintln(os.Stdout, output)
-
 	return nil
 }
 
@@ -183,6 +176,8 @@ func showHelp() {
 	fmt.Fprintln(os.Stdout, "  ccstatus             Read from stdin and generate status line")
 	fmt.Fprintln(os.Stdout, "  ccstatus help        Show this help message")
 	fmt.Fprintln(os.Stdout, "  ccstatus version     Show version information")
+	fmt.Fprintln(os.Stdout, "  ccstatus daemon      Run a resident renderer that invocations forward to (see 'ccstatus daemon -h')")
+	fmt.Fprintln(os.Stdout, "  ccstatus cache       Inspect and clear cached provider data (see 'ccstatus cache help')")
 	fmt.Fprintln(os.Stdout)
 	fmt.Fprintln(os.Stdout, "Expected JSON input format:")
 	example := core.ClaudeSession{
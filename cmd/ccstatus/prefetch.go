@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/mirage20/ccstatus-go/internal/cache"
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+)
+
+// Upper bound for a background refresh of all queued providers.
+const prefetchTimeout = 5 * time.Second
+
+// prefetch refreshes the named providers' cache entries. Providers that another
+// process is already refreshing in the same cache scope are skipped.
+func prefetch(ctx context.Context, session *core.ClaudeSession, cfgReader *config.Reader, store *cache.Store, names []string) {
+	ctx, cancel := context.WithTimeout(ctx, prefetchTimeout)
+	defer cancel()
+
+	for _, name := range names {
+		cachingProvider, unlock, locked := lockPrefetch(session, cfgReader, store, name)
+		if !locked {
+			continue
+		}
+		_, _ = cachingProvider.Refresh(ctx)
+		unlock()
+	}
+}
+
+// idlePrefetches returns the named providers that no other process is refreshing,
+// so a worker isn't started on every render just to find the refresh already running.
+func idlePrefetches(
+	session *core.ClaudeSession, cfgReader *config.Reader, store *cache.Store, names []string,
+) []string {
+	var idle []string
+	for _, name := range names {
+		if _, unlock, locked := lockPrefetch(session, cfgReader, store, name); locked {
+			unlock()
+			idle = append(idle, name)
+		}
+	}
+	return idle
+}
+
+// lockPrefetch creates the named provider and takes its prefetch lock for the provider's
+// cache scope. Returns false if the provider isn't cached or the lock is held elsewhere.
+func lockPrefetch(
+	session *core.ClaudeSession, cfgReader *config.Reader, store *cache.Store, name string,
+) (*core.CachingProvider, func(), bool) {
+	provider, exists := core.CreateProvider(name, cfgReader, session, store)
+	if !exists {
+		return nil, nil, false
+	}
+	cachingProvider, ok := provider.(*core.CachingProvider)
+	if !ok {
+		return nil, nil, false // Not cached, nothing to refresh
+	}
+
+	scope, workspace := cachingProvider.Scope()
+	unlock, locked := store.TryLock(scope, workspace, "prefetch-"+name)
+	if !locked {
+		return nil, nil, false
+	}
+	return cachingProvider, unlock, true
+}
+
+// spawnPrefetch starts a detached "ccstatus prefetch" worker for the named providers,
+// passing the session input on stdin, and returns without waiting for it.
+func spawnPrefetch(input []byte, names []string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	// Hand the input over in a temp file so the worker can read it after we exit.
+	// On Unix the file is unlinked right away; the worker keeps the open descriptor.
+	f, err := os.CreateTemp("", "ccstatus-prefetch-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+	_ = os.Remove(f.Name())
+
+	if _, err = f.Write(input); err != nil {
+		return err
+	}
+	if _, err = f.Seek(0, 0); err != nil {
+		return err
+	}
+
+	cmd := exec.Command(executable, append([]string{"prefetch"}, names...)...) //nolint:gosec // our own executable
+	cmd.Stdin = f
+	cmd.Env = append(os.Environ(), noDaemonEnv+"=1")
+	detach(cmd)
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Process.Release()
+}
+
+// runPrefetch handles the internal "ccstatus prefetch <provider>..." worker command.
+func runPrefetch(names []string) error {
+	claudeSession, err := readClaudeSession(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	cfgReader := config.NewReader(claudeSession.Workspace.ProjectDir)
+	store := cache.New(cfgReader, claudeSession.SessionID)
+	defer store.Close()
+
+	prefetch(context.Background(), claudeSession, cfgReader, store, names)
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mirage20/ccstatus-go/internal/cache"
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+)
+
+func TestIdlePrefetches(t *testing.T) {
+	project := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(project, ".claude"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	configYAML := "cache:\n  dir: " + t.TempDir() + "\n"
+	if err := os.WriteFile(filepath.Join(project, ".claude", "ccstatus.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	session := &core.ClaudeSession{SessionID: "prefetch-test"}
+	session.Workspace.CurrentDir = project
+	session.Workspace.ProjectDir = project
+
+	cfgReader := config.NewReader(project)
+	store := cache.New(cfgReader, session.SessionID)
+	t.Cleanup(func() { _ = store.Close() })
+
+	// Uncached and unknown providers are never refreshed
+	names := []string{"git", "sessioninfo", "unknown"}
+	if got := idlePrefetches(session, cfgReader, store, names); !slices.Equal(got, []string{"git"}) {
+		t.Errorf("idlePrefetches() = %v, want [git]", got)
+	}
+
+	// Another process refreshing git
+	_, unlock, locked := lockPrefetch(session, cfgReader, cache.New(cfgReader, "other"), "git")
+	if !locked {
+		t.Fatal("lockPrefetch() failed on a free lock")
+	}
+	defer unlock()
+
+	if got := idlePrefetches(session, cfgReader, store, names); len(got) != 0 {
+		t.Errorf("idlePrefetches() = %v, want none while git is locked", got)
+	}
+}
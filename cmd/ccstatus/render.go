@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+)
+
+// defaultComponents is the component order used if no active list is configured.
+var defaultComponents = []string{
+	"model",
+	"context",
+	"ratelimit.fivehour",
+	"ratelimit.sevenday",
+	"changes",
+	"duration",
+	"version",
+	"newline",
+	"cwd",
+	"git.branch",
+	"git.state",
+	"git.status",
+	"git.sync",
+	"git.stash",
+}
+
+// renderer holds the components configured for a project.
+// Components are stateless between renders, so the daemon reuses a renderer per project.
+type renderer struct {
+	cfgReader  *config.Reader
+	components []core.Component
+	providers  []string // Providers required by the components
+}
+
+// newRenderer creates the active components and collects their provider requirements.
+func newRenderer(cfgReader *config.Reader) *renderer {
+	r := &renderer{cfgReader: cfgReader}
+
+	// STEP 1: Get active components from config or use defaults
+	componentNames := config.Get(cfgReader, "active", []string{})
+	if len(componentNames) == 0 {
+		componentNames = defaultComponents
+	}
+
+	// Create components and collect their provider requirements
+	for _, name := range componentNames {
+		if comp, exists := core.CreateComponent(name, cfgReader); exists {
+			r.components = append(r.components, comp)
+
+			// Collect provider dependencies
+			for _, providerName := range comp.RequiredProviders() {
+				if !slices.Contains(r.providers, providerName) {
+					r.providers = append(r.providers, providerName)
+				}
+			}
+		}
+	}
+
+	return r
+}
+
+// render builds the status line for a session using caches from the given store.
+func (r *renderer) render(ctx context.Context, session *core.ClaudeSession, caches core.CacheStore) string {
+	// Create status line with configuration
+	statusLine := core.NewStatusLine(r.cfgReader)
+
+	// STEP 2: Create only the providers that components need
+	for _, providerName := range r.providers {
+		// Create provider from registry (registry handles caching)
+		if provider, exists := core.CreateProvider(providerName, r.cfgReader, session, caches); exists {
+			statusLine.AddProvider(provider)
+		} else {
+			// Log warning that a required provider is not registered
+			fmt.Fprintf(os.Stderr, "Warning: Component requires provider '%s' but it's not registered\n", providerName)
+		}
+	}
+
+	// STEP 3: Add components to statusline
+	for _, comp := range r.components {
+		statusLine.AddComponent(comp)
+	}
+
+	return statusLine.Render(ctx)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mirage20/ccstatus-go/internal/cache"
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+)
+
+// Allocation budgets for one render with warm caches.
+// Raise them only when a change needs the allocations; they guard against regressions.
+const (
+	sessionAllocBudget = 200
+	gitAllocBudget     = 350
+)
+
+// sessionComponents render from the Claude session only.
+var sessionComponents = []string{
+	"model",
+	"context",
+	"ratelimit.fivehour",
+	"ratelimit.sevenday",
+	"changes",
+	"duration",
+	"version",
+	"newline",
+	"cwd",
+}
+
+// benchSetup creates a project using the given components and returns a renderer,
+// session and cache store for it. The project is a git repository if git is set.
+func benchSetup(tb testing.TB, components []string, git bool) (*renderer, *core.ClaudeSession, *cache.Store) {
+	tb.Helper()
+
+	project := tb.TempDir()
+	if git {
+		if _, err := exec.LookPath("git"); err != nil {
+			tb.Skip("git not installed")
+		}
+		for _, args := range [][]string{
+			{"init", "-q", "-b", "main"},
+			{"-c", "user.name=bench", "-c", "user.email=bench@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+		} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = project
+			if out, err := cmd.CombinedOutput(); err != nil {
+				tb.Fatalf("git %v: %v\n%s", args, err, out)
+			}
+		}
+	}
+
+	cfg := "active: [" + strings.Join(components, ", ") + "]\n" +
+		"cache:\n  dir: " + tb.TempDir() + "\n"
+	if err := os.MkdirAll(filepath.Join(project, ".claude"), 0o750); err != nil {
+		tb.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, ".claude", "ccstatus.yaml"), []byte(cfg), 0o600); err != nil {
+		tb.Fatal(err)
+	}
+
+	resetsAt := int64(4102444800) // 2100-01-01
+	session := &core.ClaudeSession{
+		SessionID: "bench-session",
+		Model:     core.ModelInfo{ID: "claude-opus-4-1-20250805", DisplayName: "Opus 4.1"},
+		Workspace: core.Workspace{CurrentDir: project, ProjectDir: project},
+		Version:   "1.0.89",
+		Cost: core.CostInfo{
+			TotalCostUSD:       0.12,
+			TotalDurationMs:    60000,
+			TotalAPIDurationMs: 15000,
+			TotalLinesAdded:    50,
+			TotalLinesRemoved:  10,
+		},
+		ContextWindow: core.ContextWindow{
+			ContextWindowSize: 200000,
+			CurrentUsage:      &core.ContextUsage{InputTokens: 40000, OutputTokens: 2000},
+		},
+		RateLimits: &core.SessionRateLimits{
+			FiveHour: &core.SessionRateLimit{UsedPercentage: 42, ResetsAt: &resetsAt},
+			SevenDay: &core.SessionRateLimit{UsedPercentage: 73, ResetsAt: &resetsAt},
+		},
+	}
+
+	cfgReader := config.NewReader(project)
+	caches := cache.New(cfgReader, session.SessionID)
+	tb.Cleanup(func() { _ = caches.Close() })
+
+	r := newRenderer(cfgReader)
+	if output := r.render(context.Background(), session, caches); output == "" {
+		tb.Fatal("render produced no output")
+	}
+	return r, session, caches
+}
+
+func BenchmarkRender(b *testing.B) {
+	r, session, caches := benchSetup(b, sessionComponents, false)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		r.render(ctx, session, caches)
+	}
+}
+
+func BenchmarkRenderGitWarmCache(b *testing.B) {
+	r, session, caches := benchSetup(b, defaultComponents, true)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		r.render(ctx, session, caches)
+	}
+}
+
+func TestRenderAllocations(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []string
+		git        bool
+		budget     float64
+	}{
+		{"session", sessionComponents, false, sessionAllocBudget},
+		{"git warm cache", defaultComponents, true, gitAllocBudget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, session, caches := benchSetup(t, tt.components, tt.git)
+			ctx := context.Background()
+
+			allocs := testing.AllocsPerRun(20, func() {
+				r.render(ctx, session, caches)
+			})
+			if allocs > tt.budget {
+				t.Errorf("render allocated %.0f times, budget is %.0f", allocs, tt.budget)
+			}
+		})
+	}
+}
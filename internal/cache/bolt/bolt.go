@@ -0,0 +1,279 @@
+package bolt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bbolt "go.etcd.io/bbolt"
+)
+
+const (
+	// FileName is the database file name inside the cache directory.
+	FileName = "ccstatus.db"
+
+	// How long to wait for another invocation to release the database.
+	openTimeout = 250 * time.Millisecond
+
+	// Minimum time between expiry sweeps of the whole database.
+	sweepInterval = 10 * time.Minute
+)
+
+// Bucket and key holding database-wide metadata.
+var (
+	metaBucket   = []byte("__ccstatus_meta")
+	lastSweepKey = []byte("last_sweep")
+)
+
+// Cache implements core.Cache on a bbolt database shared by all sessions.
+// Each session (or scope name) gets its own bucket. Entries are read once when the
+// cache is created and written back in a single transaction on Close, so the
+// database is only locked briefly instead of for the whole invocation.
+type Cache struct {
+	path      string
+	sessionID string
+	entries   map[string]*entry
+	dirty     map[string]bool // Keys set or deleted since load
+	mu        sync.RWMutex
+}
+
+// entry represents a cached item.
+type entry struct {
+	Data      json.RawMessage `json:"data"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	CachedAt  time.Time       `json:"cached_at"`
+}
+
+// NewCache creates a bolt cache for a session using the database in baseDir.
+func NewCache(baseDir, sessionID string) *Cache {
+	c := &Cache{
+		path:      filepath.Join(baseDir, FileName),
+		sessionID: sessionID,
+		entries:   make(map[string]*entry),
+		dirty:     make(map[string]bool),
+	}
+
+	// Load existing entries if available
+	_ = c.load()
+
+	return c
+}
+
+// load reads the session's unexpired entries into memory.
+func (c *Cache) load() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := os.Stat(c.path); os.IsNotExist(err) {
+		// No database yet, that's fine
+		return nil
+	}
+
+	db, err := bbolt.Open(c.path, 0600, &bbolt.Options{Timeout: openTimeout, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to open cache database: %w", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	return db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(c.sessionID))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			var e entry
+			if json.Unmarshal(v, &e) == nil && now.Before(e.ExpiresAt) {
+				c.entries[string(k)] = &e
+			}
+			return nil
+		})
+	})
+}
+
+// Get retrieves cached data from memory and unmarshals into target.
+func (c *Cache) Get(key string, target any) (bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, exists := c.entries[key]
+	if !exists || time.Now().After(e.ExpiresAt) {
+		return false, nil
+	}
+
+	err := json.Unmarshal(e.Data, target)
+	return err == nil, err
+}
+
+// Set stores data in memory until Close.
+func (c *Cache) Set(key string, value any, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	c.entries[key] = &entry{
+		Data:      jsonData,
+		ExpiresAt: now.Add(ttl),
+		CachedAt:  now,
+	}
+	c.dirty[key] = true
+
+	return nil
+}
+
+// Delete removes cached data.
+func (c *Cache) Delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	c.dirty[key] = true
+
+	return nil
+}
+
+// Close writes changed entries in one transaction and sweeps expired entries
+// from all sessions when the last sweep is older than sweepInterval.
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.dirty) == 0 {
+		return nil // Nothing to save
+	}
+
+	// Ensure cache directory exists (lazy creation)
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	db, err := bbolt.Open(c.path, 0600, &bbolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to open cache database: %w", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	err = db.Update(func(tx *bbolt.Tx) error {
+		if saveErr := c.save(tx); saveErr != nil {
+			return saveErr
+		}
+		return sweepIfDue(tx, now)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save cache: %w", err)
+	}
+
+	c.dirty = make(map[string]bool)
+	return nil
+}
+
+// save writes changed entries to the session's bucket. An entry written by another
+// invocation with a newer CachedAt is kept. Caller must hold c.mu.
+func (c *Cache) save(tx *bbolt.Tx) error {
+	b, err := tx.CreateBucketIfNotExists([]byte(c.sessionID))
+	if err != nil {
+		return err
+	}
+
+	for key := range c.dirty {
+		e, exists := c.entries[key]
+		if !exists {
+			if err = b.Delete([]byte(key)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var stored entry
+		if v := b.Get([]byte(key)); v != nil && json.Unmarshal(v, &stored) == nil && stored.CachedAt.After(e.CachedAt) {
+			continue
+		}
+
+		value, marshalErr := json.Marshal(e)
+		if marshalErr != nil {
+			return marshalErr
+		}
+		if err = b.Put([]byte(key), value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sweepIfDue removes expired entries and empty session buckets, at most once per sweepInterval.
+func sweepIfDue(tx *bbolt.Tx, now time.Time) error {
+	meta, err := tx.CreateBucketIfNotExists(metaBucket)
+	if err != nil {
+		return err
+	}
+
+	var lastSweep time.Time
+	if v := meta.Get(lastSweepKey); v != nil {
+		_ = lastSweep.UnmarshalText(v)
+	}
+	if now.Sub(lastSweep) < sweepInterval {
+		return nil
+	}
+
+	if err = sweep(tx, now); err != nil {
+		return err
+	}
+
+	stamp, _ := now.MarshalText()
+	return meta.Put(lastSweepKey, stamp)
+}
+
+// sweep removes entries expired at now and session buckets left empty.
+func sweep(tx *bbolt.Tx, now time.Time) error {
+	var emptyBuckets [][]byte
+
+	err := tx.ForEach(func(name []byte, b *bbolt.Bucket) error {
+		if string(name) == string(metaBucket) {
+			return nil
+		}
+
+		// Collect first; keys can't be deleted while iterating with ForEach
+		var expired [][]byte
+		remaining := 0
+		_ = b.ForEach(func(k, v []byte) error {
+			var e entry
+			if json.Unmarshal(v, &e) != nil || !now.Before(e.ExpiresAt) {
+				expired = append(expired, k)
+			} else {
+				remaining++
+			}
+			return nil
+		})
+
+		for _, k := range expired {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		if remaining == 0 {
+			emptyBuckets = append(emptyBuckets, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range emptyBuckets {
+		if err = tx.DeleteBucket(name); err != nil && !errors.Is(err, bbolt.ErrBucketNotFound) {
+			return err
+		}
+	}
+	return nil
+}
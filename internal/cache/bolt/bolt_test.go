@@ -0,0 +1,115 @@
+package bolt
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	bbolt "go.etcd.io/bbolt"
+)
+
+func TestCache_PersistsPerSession(t *testing.T) {
+	dir := t.TempDir()
+
+	c := NewCache(dir, "one")
+	_ = c.Set("key", "first", time.Minute)
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	other := NewCache(dir, "two")
+	_ = other.Set("key", "second", time.Minute)
+	if err := other.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	for session, want := range map[string]string{"one": "first", "two": "second"} {
+		var got string
+		if ok, _ := NewCache(dir, session).Get("key", &got); !ok || got != want {
+			t.Errorf("session %s: Get() = %q, %v; want %q", session, got, ok, want)
+		}
+	}
+}
+
+func TestCache_KeepsNewerEntries(t *testing.T) {
+	dir := t.TempDir()
+
+	// Both invocations load before either closes
+	stale := NewCache(dir, "session")
+	fresh := NewCache(dir, "session")
+
+	_ = stale.Set("key", "stale", time.Minute)
+	_ = stale.Set("other", "value", time.Minute)
+	stale.entries["key"].CachedAt = time.Now().Add(-time.Second)
+	_ = fresh.Set("key", "fresh", time.Minute)
+
+	if err := fresh.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if err := stale.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	c := NewCache(dir, "session")
+	var key, other string
+	_, _ = c.Get("key", &key)
+	_, _ = c.Get("other", &other)
+	if key != "fresh" || other != "value" {
+		t.Errorf("Get() = key %q, other %q; want fresh, value", key, other)
+	}
+}
+
+func TestCache_Delete(t *testing.T) {
+	dir := t.TempDir()
+
+	c := NewCache(dir, "session")
+	_ = c.Set("key", "value", time.Minute)
+	_ = c.Close()
+
+	c = NewCache(dir, "session")
+	_ = c.Delete("key")
+	_ = c.Close()
+
+	var got string
+	if ok, _ := NewCache(dir, "session").Get("key", &got); ok {
+		t.Errorf("Get() found deleted entry %q", got)
+	}
+}
+
+func TestSweep(t *testing.T) {
+	dir := t.TempDir()
+
+	expired := NewCache(dir, "expired")
+	_ = expired.Set("key", "value", time.Millisecond)
+	_ = expired.Close()
+
+	live := NewCache(dir, "live")
+	_ = live.Set("old", "value", time.Millisecond)
+	_ = live.Set("new", "value", time.Hour)
+	_ = live.Close()
+
+	db, err := bbolt.Open(filepath.Join(dir, FileName), 0600, nil)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer db.Close()
+
+	later := time.Now().Add(time.Second)
+	if err = db.Update(func(tx *bbolt.Tx) error { return sweep(tx, later) }); err != nil {
+		t.Fatalf("sweep() error: %v", err)
+	}
+
+	_ = db.View(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte("expired")) != nil {
+			t.Error("bucket with only expired entries was not removed")
+		}
+		b := tx.Bucket([]byte("live"))
+		if b == nil {
+			t.Fatal("bucket with live entries was removed")
+		}
+		if b.Get([]byte("old")) != nil || b.Get([]byte("new")) == nil {
+			t.Error("sweep() removed the wrong entries")
+		}
+		return nil
+	})
+}
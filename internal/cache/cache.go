@@ -3,22 +3,69 @@ package cache
 import (
 	"os"
 
+	"github.com/mirage20/ccstatus-go/internal/cache/bolt"
 	"github.com/mirage20/ccstatus-go/internal/cache/file"
 	"github.com/mirage20/ccstatus-go/internal/cache/null"
 	"github.com/mirage20/ccstatus-go/internal/config"
 	"github.com/mirage20/ccstatus-go/internal/core"
 )
 
-// New creates a cache instance based on configuration.
-// Returns NullCache if cache.enabled is false, otherwise returns FileCache.
-// Default behavior is to enable cache.
-func New(cfg *config.Reader, sessionID string) core.Cache {
+// Supported values for cache.backend.
+const (
+	BackendFile = "file" // One JSON file per session (default)
+	BackendBolt = "bolt" // One embedded database with a bucket per session
+)
+
+// New creates a cache store based on configuration.
+// Hands out NullCaches if cache.enabled is false, otherwise caches of the
+// configured cache.backend. Default behavior is to enable file caching.
+func New(cfg *config.Reader, sessionID string) *Store {
 	// Default true - cache enabled unless explicitly disabled
 	if !config.Get(cfg, "cache.enabled", true) {
-		return null.NewCache()
+		return NewStore(sessionID, func(string) core.Cache {
+			return null.NewCache()
+		})
 	}
 
-	// Use file cache with configured or default directory
+	// Use configured or default directory
 	dir := config.Get(cfg, "cache.dir", os.TempDir())
-	return file.NewCache(dir, sessionID)
+
+	policy := config.Get(cfg, "cache.cleanup", file.DefaultCleanupPolicy())
+
+	if Backend(cfg) == BackendBolt {
+		store := NewStore(sessionID, func(name string) core.Cache {
+			return bolt.NewCache(dir, name)
+		})
+		store.lockDir = dir
+		store.source = BackendBolt + ":" + dir
+
+		// Bolt sweeps expired entries itself; only the lock files need removing
+		store.OnClose(func([]string) {
+			_, _ = file.CleanupLocksIfDue(dir, policy.Interval)
+		})
+		return store
+	}
+
+	store := NewStore(sessionID, func(name string) core.Cache {
+		return file.NewCache(dir, name)
+	})
+	store.lockDir = dir
+	store.source = BackendFile + ":" + dir
+
+	// Remove old cache and lock files on schedule, keeping the ones this invocation used
+	store.OnClose(func(names []string) {
+		// Ignore cleanup errors - they shouldn't affect the status line
+		_, _ = file.CleanupIfDue(dir, policy, names...)
+		_, _ = file.CleanupLocksIfDue(dir, policy.Interval)
+	})
+
+	return store
+}
+
+// Backend returns the configured cache backend, defaulting to BackendFile.
+func Backend(cfg *config.Reader) string {
+	if config.Get(cfg, "cache.backend", BackendFile) == BackendBolt {
+		return BackendBolt
+	}
+	return BackendFile
 }
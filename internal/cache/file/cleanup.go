@@ -0,0 +1,105 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Name of the marker file whose mtime records the last cleanup run.
+const cleanupMarker = ".ccstatus_cleanup"
+
+// Default cleanup policy values.
+const (
+	defaultCleanupMaxAge   = 24 * time.Hour
+	defaultCleanupInterval = time.Hour
+)
+
+// CleanupPolicy controls which cache files are removed and how often cleanup runs.
+type CleanupPolicy struct {
+	// Remove files not modified within this duration (0 = no age limit)
+	MaxAge time.Duration `yaml:"max_age"`
+
+	// Keep at most this many files, removing the oldest first (0 = no limit)
+	MaxFiles int `yaml:"max_files"`
+
+	// Keep total size of cache files under this many bytes, removing the oldest first (0 = no limit)
+	MaxBytes int64 `yaml:"max_bytes"`
+
+	// Minimum time between cleanup runs (0 = every invocation)
+	Interval time.Duration `yaml:"interval"`
+}
+
+// DefaultCleanupPolicy returns the default cleanup policy.
+func DefaultCleanupPolicy() CleanupPolicy {
+	return CleanupPolicy{
+		MaxAge:   defaultCleanupMaxAge,
+		Interval: defaultCleanupInterval,
+	}
+}
+
+// CleanupIfDue runs Cleanup if the last run recorded by the marker file in baseDir
+// is older than the policy interval. Reports whether cleanup ran.
+// The marker is touched before cleaning, so concurrent invocations rarely both run.
+func CleanupIfDue(baseDir string, policy CleanupPolicy, keep ...string) (bool, error) {
+	marker := filepath.Join(baseDir, cleanupMarker)
+	now := time.Now()
+
+	if info, err := os.Stat(marker); err == nil && now.Sub(info.ModTime()) < policy.Interval {
+		return false, nil
+	}
+
+	// Record this run
+	if err := os.MkdirAll(baseDir, 0700); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(marker, nil, 0600); err != nil {
+		return false, err
+	}
+	if err := os.Chtimes(marker, now, now); err != nil {
+		return false, err
+	}
+
+	return true, Cleanup(baseDir, policy, keep...)
+}
+
+// Cleanup removes cache files in baseDir that violate the policy: first files older
+// than MaxAge, then the oldest files until at most MaxFiles remain and their total
+// size is at most MaxBytes. Caches named in keep are never removed.
+func Cleanup(baseDir string, policy CleanupPolicy, keep ...string) error {
+	files, err := List(baseDir)
+	if err != nil {
+		return err
+	}
+
+	// Oldest first
+	slices.Reverse(files)
+
+	var totalBytes int64
+	for _, f := range files {
+		totalBytes += f.Size
+	}
+
+	cutoff := time.Now().Add(-policy.MaxAge)
+	remaining := len(files)
+	for _, f := range files {
+		if slices.Contains(keep, f.Name) {
+			continue
+		}
+
+		expired := policy.MaxAge > 0 && f.ModTime.Before(cutoff)
+		tooMany := policy.MaxFiles > 0 && remaining > policy.MaxFiles
+		tooBig := policy.MaxBytes > 0 && totalBytes > policy.MaxBytes
+		if !expired && !tooMany && !tooBig {
+			continue
+		}
+
+		if Remove(baseDir, f.Name) == nil {
+			remaining--
+			totalBytes -= f.Size
+		}
+	}
+
+	return nil
+}
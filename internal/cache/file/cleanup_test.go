@@ -0,0 +1,117 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// writeCacheFile saves a cache file and sets its modification time to age ago.
+func writeCacheFile(t *testing.T, dir, name string, age time.Duration) {
+	t.Helper()
+	c := NewCache(dir, name)
+	_ = c.Set("key", name, time.Hour)
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	modTime := time.Now().Add(-age)
+	if err := os.Chtimes(filepath.Join(dir, cacheFileName(name)), modTime, modTime); err != nil {
+		t.Fatalf("Chtimes() error: %v", err)
+	}
+}
+
+// remainingNames returns the names of cache files left in dir, sorted.
+func remainingNames(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := List(dir)
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, f.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestCleanup(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy CleanupPolicy
+		keep   []string
+		want   []string
+	}{
+		{
+			name:   "max age",
+			policy: CleanupPolicy{MaxAge: 24 * time.Hour},
+			want:   []string{"new", "recent"},
+		},
+		{
+			name:   "max age keeps current",
+			policy: CleanupPolicy{MaxAge: 24 * time.Hour},
+			keep:   []string{"old"},
+			want:   []string{"new", "old", "recent"},
+		},
+		{
+			name:   "max files removes oldest",
+			policy: CleanupPolicy{MaxFiles: 1},
+			want:   []string{"new"},
+		},
+		{
+			name:   "max bytes removes oldest",
+			policy: CleanupPolicy{MaxBytes: 1},
+			keep:   []string{"recent"},
+			want:   []string{"recent"},
+		},
+		{
+			name:   "no limits",
+			policy: CleanupPolicy{},
+			want:   []string{"new", "old", "recent"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeCacheFile(t, dir, "old", 48*time.Hour)
+			writeCacheFile(t, dir, "recent", time.Hour)
+			writeCacheFile(t, dir, "new", 0)
+
+			if err := Cleanup(dir, tt.policy, tt.keep...); err != nil {
+				t.Fatalf("Cleanup() error: %v", err)
+			}
+			if got := remainingNames(t, dir); !slices.Equal(got, tt.want) {
+				t.Errorf("remaining = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanupIfDue(t *testing.T) {
+	dir := t.TempDir()
+	policy := CleanupPolicy{MaxAge: time.Hour, Interval: time.Hour}
+
+	writeCacheFile(t, dir, "old", 48*time.Hour)
+	if ran, err := CleanupIfDue(dir, policy); !ran || err != nil {
+		t.Fatalf("first CleanupIfDue() = %v, %v; want run", ran, err)
+	}
+	if got := remainingNames(t, dir); len(got) != 0 {
+		t.Errorf("remaining = %v, want none", got)
+	}
+
+	// Within the interval cleanup is skipped
+	writeCacheFile(t, dir, "old", 48*time.Hour)
+	if ran, _ := CleanupIfDue(dir, policy); ran {
+		t.Error("CleanupIfDue() ran again within interval")
+	}
+
+	// Once the marker is older than the interval it runs again
+	past := time.Now().Add(-2 * time.Hour)
+	_ = os.Chtimes(filepath.Join(dir, cleanupMarker), past, past)
+	if ran, _ := CleanupIfDue(dir, policy); !ran {
+		t.Error("CleanupIfDue() didn't run after interval")
+	}
+}
@@ -9,11 +9,18 @@ import (
 	"time"
 )
 
+// Cache file naming: ccstatus_<name>.json.
+const (
+	cacheFilePrefix = "ccstatus_"
+	cacheFileSuffix = ".json"
+)
+
 // Cache implements file-based caching with session isolation.
 type Cache struct {
 	baseDir   string
 	sessionID string
 	entries   map[string]*entry
+	deleted   map[string]time.Time // Keys deleted since the last save, with deletion time
 	dirty     bool
 	mu        sync.RWMutex
 }
@@ -34,11 +41,13 @@ type data struct {
 }
 
 // NewCache creates a new file cache with session isolation.
+// sessionID names the cache file and may also be a shared scope name (e.g. "global").
 func NewCache(baseDir, sessionID string) *Cache {
 	fc := &Cache{
 		baseDir:   baseDir,
 		sessionID: sessionID,
 		entries:   make(map[string]*entry),
+		deleted:   make(map[string]time.Time),
 	}
 
 	// Load existing cache if available
@@ -52,40 +61,56 @@ func (fc *Cache) load() error {
 	fc.mu.Lock()
 	defer fc.mu.Unlock()
 
+	entries, err := fc.readEntries()
+	if err != nil {
+		return err
+	}
+	fc.entries = entries
+
+	return nil
+}
+
+// readEntries reads the unexpired entries from the cache file.
+// A missing, corrupted or foreign file yields no entries.
+func (fc *Cache) readEntries() (map[string]*entry, error) {
+	entries := make(map[string]*entry)
+
 	path := fc.getCachePath()
 	fileData, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
 		// No cache file yet, that's fine
-		return nil
+		return entries, nil
 	}
 	if err != nil {
-		return fmt.Errorf("failed to read cache file: %w", err)
+		return entries, fmt.Errorf("failed to read cache file: %w", err)
 	}
 
 	var cacheData data
 	if err = json.Unmarshal(fileData, &cacheData); err != nil {
 		// Corrupted cache file, start fresh
-		return nil
+		return entries, nil
 	}
 
 	// Validate session ID matches
 	if cacheData.SessionID != fc.sessionID {
 		// Different session, start fresh
-		return nil
+		return entries, nil
 	}
 
 	// Load entries, filtering out expired ones
 	now := time.Now()
 	for key, e := range cacheData.Providers {
-		if now.Before(e.ExpiresAt) {
-			fc.entries[key] = e
+		if e != nil && now.Before(e.ExpiresAt) {
+			entries[key] = e
 		}
 	}
 
-	return nil
+	return entries, nil
 }
 
-// Save writes all cache entries to disk.
+// Save merges cache entries with the file on disk and writes the result.
+// The file is locked for the whole read-merge-write, so concurrent invocations
+// sharing a cache keep each other's entries; the newest CachedAt wins per key.
 func (fc *Cache) Save() error {
 	fc.mu.Lock()
 	defer fc.mu.Unlock()
@@ -94,19 +119,29 @@ func (fc *Cache) Save() error {
 		return nil // Nothing to save
 	}
 
-	// Clean expired entries before saving
-	now := time.Now()
-	activeEntries := make(map[string]*entry)
-	for key, e := range fc.entries {
-		if now.Before(e.ExpiresAt) {
-			activeEntries[key] = e
-		}
+	// Ensure cache and lock directories exist (lazy creation)
+	if err := os.MkdirAll(LockDir(fc.baseDir), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	path := fc.getCachePath()
+	unlock, err := lockFile(saveLockPath(fc.baseDir, fc.sessionID))
+	if err != nil {
+		return fmt.Errorf("failed to lock cache file: %w", err)
 	}
+	defer unlock()
+
+	// Entries on disk may have been written by other invocations since load
+	onDisk, err := fc.readEntries()
+	if err != nil {
+		return err
+	}
+	merged := fc.merge(onDisk)
 
 	cacheData := data{
 		SessionID:   fc.sessionID,
-		LastUpdated: now,
-		Providers:   activeEntries,
+		LastUpdated: time.Now(),
+		Providers:   merged,
 		Version:     "2.0",
 	}
 
@@ -115,14 +150,8 @@ func (fc *Cache) Save() error {
 		return fmt.Errorf("failed to marshal cache: %w", err)
 	}
 
-	path := fc.getCachePath()
 	tempPath := path + ".tmp"
 
-	// Ensure cache directory exists (lazy creation)
-	if err = os.MkdirAll(fc.baseDir, 0700); err != nil {
-		return fmt.Errorf("failed to create cache directory: %w", err)
-	}
-
 	// Write to temp file first
 	if err = os.WriteFile(tempPath, jsonData, 0600); err != nil {
 		return fmt.Errorf("failed to write cache file: %w", err)
@@ -136,10 +165,40 @@ func (fc *Cache) Save() error {
 	}
 
 	// Only mark as clean after successful save
+	fc.entries = merged
+	fc.deleted = make(map[string]time.Time)
 	fc.dirty = false
 	return nil
 }
 
+// merge combines entries read from disk with in-memory entries.
+// For each key the entry with the newest CachedAt wins. Keys deleted in memory are
+// dropped unless another invocation cached them again after the deletion.
+// Expired entries are left out. Caller must hold fc.mu.
+func (fc *Cache) merge(onDisk map[string]*entry) map[string]*entry {
+	now := time.Now()
+	merged := make(map[string]*entry, len(onDisk)+len(fc.entries))
+
+	for key, e := range onDisk {
+		if deletedAt, ok := fc.deleted[key]; ok && !e.CachedAt.After(deletedAt) {
+			continue
+		}
+		merged[key] = e
+	}
+
+	for key, e := range fc.entries {
+		if !now.Before(e.ExpiresAt) {
+			continue
+		}
+		if existing, ok := merged[key]; ok && existing.CachedAt.After(e.CachedAt) {
+			continue
+		}
+		merged[key] = e
+	}
+
+	return merged
+}
+
 // Get retrieves cached data from in-memory cache and unmarshals into target.
 func (fc *Cache) Get(key string, target any) (bool, error) {
 	fc.mu.RLock()
@@ -186,70 +245,32 @@ func (fc *Cache) Delete(key string) error {
 	fc.mu.Lock()
 	defer fc.mu.Unlock()
 
-	if _, exists := fc.entries[key]; exists {
-		delete(fc.entries, key)
-		fc.dirty = true
-	}
-
-	return nil
-}
-
-// Cleanup removes old cache files (older than 24 hours).
-func (fc *Cache) Cleanup() error {
-	fc.mu.RLock()
-	baseDir := fc.baseDir
-	sessionID := fc.sessionID
-	fc.mu.RUnlock()
-
-	pattern := filepath.Join(baseDir, "ccstatus_*.json")
-	files, err := filepath.Glob(pattern)
-	if err != nil {
-		return err
-	}
-
-	cutoff := time.Now().Add(-24 * time.Hour)
-	currentFile := filepath.Join(baseDir, fmt.Sprintf("ccstatus_%s.json", sessionID))
-
-	for _, f := range files {
-		// Don't delete current session's cache
-		if f == currentFile {
-			continue
-		}
-
-		info, statErr := os.Stat(f)
-		if statErr != nil {
-			continue
-		}
-
-		// Remove files older than 24 hours
-		if info.ModTime().Before(cutoff) {
-			_ = os.Remove(f)
-		}
-	}
+	// Record the deletion even if the key isn't in memory, so saving
+	// removes an entry another invocation may have written meanwhile
+	delete(fc.entries, key)
+	fc.deleted[key] = time.Now()
+	fc.dirty = true
 
 	return nil
 }
 
-// Close performs save and cleanup operations.
-// It saves any pending changes and optionally cleans up old cache files.
+// Close saves any pending changes.
+// Old cache files are removed separately by CleanupIfDue.
 func (fc *Cache) Close() error {
-	// Save any pending changes
 	if err := fc.Save(); err != nil {
 		return fmt.Errorf("failed to save cache: %w", err)
 	}
 
-	// Cleanup old cache files occasionally (10% chance based on session ID)
-	if len(fc.sessionID) > 0 && fc.sessionID[len(fc.sessionID)-1]%10 == 0 {
-		// Ignore cleanup errors - they shouldn't prevent close
-		_ = fc.Cleanup()
-	}
-
 	return nil
 }
 
 // getCachePath generates the cache file path for this session.
 func (fc *Cache) getCachePath() string {
 	// Use session ID in filename for easy identification
-	filename := fmt.Sprintf("ccstatus_%s.json", fc.sessionID)
-	return filepath.Join(fc.baseDir, filename)
+	return filepath.Join(fc.baseDir, cacheFileName(fc.sessionID))
+}
+
+// cacheFileName returns the file name for a cache name.
+func cacheFileName(name string) string {
+	return cacheFilePrefix + name + cacheFileSuffix
 }
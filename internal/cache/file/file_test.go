@@ -0,0 +1,151 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Environment variables used to run the test binary as a hammer worker process.
+const (
+	hammerDirEnv    = "CCSTATUS_HAMMER_DIR"
+	hammerWorkerEnv = "CCSTATUS_HAMMER_WORKER"
+)
+
+const (
+	hammerWorkers = 8
+	hammerRounds  = 20
+)
+
+func TestCache_MergeOnSave(t *testing.T) {
+	dir := t.TempDir()
+
+	// Both invocations load the same (empty) file before either saves
+	first := NewCache(dir, "session")
+	second := NewCache(dir, "session")
+
+	if err := first.Set("a", "first", time.Minute); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+	if err := second.Set("b", "second", time.Minute); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+	if err := first.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if err := second.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	got := NewCache(dir, "session")
+	for key, want := range map[string]string{"a": "first", "b": "second"} {
+		var value string
+		if ok, _ := got.Get(key, &value); !ok || value != want {
+			t.Errorf("Get(%q) = %q, %v; want %q", key, value, ok, want)
+		}
+	}
+}
+
+func TestCache_MergeNewestWins(t *testing.T) {
+	dir := t.TempDir()
+
+	stale := NewCache(dir, "session")
+	_ = stale.Set("key", "stale", time.Minute)
+
+	fresh := NewCache(dir, "session")
+	_ = fresh.Set("key", "fresh", time.Minute)
+	if err := fresh.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	// Saving the older entry later must not overwrite the newer one
+	_ = stale.Set("other", "value", time.Minute)
+	stale.entries["key"].CachedAt = time.Now().Add(-time.Second)
+	if err := stale.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	var value string
+	if _, _ = NewCache(dir, "session").Get("key", &value); value != "fresh" {
+		t.Errorf("Get() = %q, want %q", value, "fresh")
+	}
+}
+
+func TestCache_MergeDelete(t *testing.T) {
+	dir := t.TempDir()
+
+	writer := NewCache(dir, "session")
+	_ = writer.Set("key", "value", time.Minute)
+	if err := writer.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	deleter := NewCache(dir, "session")
+	_ = deleter.Delete("key")
+	if err := deleter.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	var value string
+	if ok, _ := NewCache(dir, "session").Get("key", &value); ok {
+		t.Errorf("Get() found deleted entry %q", value)
+	}
+}
+
+// TestCache_Hammer saves the same cache from many processes at once and checks
+// that no process loses another's entries.
+func TestCache_Hammer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spawns processes")
+	}
+	dir := t.TempDir()
+
+	cmds := make([]*exec.Cmd, hammerWorkers)
+	for i := range cmds {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestCache_HammerWorker$")
+		cmd.Env = append(os.Environ(), hammerDirEnv+"="+dir, hammerWorkerEnv+"="+strconv.Itoa(i))
+		if err := cmd.Start(); err != nil {
+			t.Fatalf("failed to start worker: %v", err)
+		}
+		cmds[i] = cmd
+	}
+	for i, cmd := range cmds {
+		if err := cmd.Wait(); err != nil {
+			t.Fatalf("worker %d failed: %v", i, err)
+		}
+	}
+
+	c := NewCache(dir, "hammer")
+	for worker := range hammerWorkers {
+		for round := range hammerRounds {
+			key := fmt.Sprintf("w%d-r%d", worker, round)
+			var value int
+			if ok, _ := c.Get(key, &value); !ok || value != round {
+				t.Errorf("Get(%q) = %d, %v; want %d", key, value, ok, round)
+			}
+		}
+	}
+}
+
+// TestCache_HammerWorker is run as a subprocess by TestCache_Hammer.
+func TestCache_HammerWorker(t *testing.T) {
+	dir := os.Getenv(hammerDirEnv)
+	if dir == "" {
+		t.Skip("only runs as a subprocess of TestCache_Hammer")
+	}
+	worker := os.Getenv(hammerWorkerEnv)
+
+	// Each round is a separate invocation: load, set one key, save
+	for round := range hammerRounds {
+		c := NewCache(dir, "hammer")
+		if err := c.Set(fmt.Sprintf("w%s-r%d", worker, round), round, time.Minute); err != nil {
+			t.Fatalf("Set() error: %v", err)
+		}
+		if err := c.Save(); err != nil {
+			t.Fatalf("Save() error: %v", err)
+		}
+	}
+}
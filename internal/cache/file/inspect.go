@@ -0,0 +1,136 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FileInfo describes a cache file on disk.
+type FileInfo struct {
+	Name        string      // Session ID or scope name (e.g. "global")
+	Path        string      // Absolute path of the cache file
+	Size        int64       // File size in bytes
+	ModTime     time.Time   // File modification time (used by cleanup)
+	LastUpdated time.Time   // Last save recorded in the file
+	Version     string      // Cache format version
+	Entries     []EntryInfo // Entries sorted by key, including expired ones
+}
+
+// EntryInfo describes one cached provider entry.
+type EntryInfo struct {
+	Key       string
+	Data      json.RawMessage
+	CachedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the entry has expired at now.
+func (e EntryInfo) Expired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
+
+// List returns all cache files in baseDir, most recently modified first.
+// Files that can't be parsed are listed without entries.
+func List(baseDir string) ([]FileInfo, error) {
+	paths, err := filepath.Glob(filepath.Join(baseDir, cacheFileName("*")))
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]FileInfo, 0, len(paths))
+	for _, path := range paths {
+		info, readErr := readFileInfo(path)
+		if readErr != nil {
+			continue
+		}
+		infos = append(infos, *info)
+	}
+
+	slices.SortFunc(infos, func(a, b FileInfo) int {
+		return b.ModTime.Compare(a.ModTime)
+	})
+	return infos, nil
+}
+
+// Inspect returns the cache file for a name in baseDir.
+func Inspect(baseDir, name string) (*FileInfo, error) {
+	return readFileInfo(filepath.Join(baseDir, cacheFileName(name)))
+}
+
+// Remove deletes the cache file for a name in baseDir along with its temp files.
+// The save lock is held meanwhile, so no invocation is writing the file. Lock files
+// are kept: removing one another process holds would break the lock; CleanupLocks
+// removes them once they're unused.
+func Remove(baseDir, name string) error {
+	if err := os.MkdirAll(LockDir(baseDir), 0700); err != nil {
+		return err
+	}
+	unlock, err := lockFile(saveLockPath(baseDir, name))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	path := filepath.Join(baseDir, cacheFileName(name))
+	if err = os.Remove(path); err != nil {
+		return err
+	}
+	related, _ := filepath.Glob(path + ".*")
+	for _, f := range related {
+		if !strings.HasSuffix(f, ".lock") {
+			_ = os.Remove(f)
+		}
+	}
+	return nil
+}
+
+// readFileInfo stats and parses a cache file.
+func readFileInfo(path string) (*FileInfo, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	base := filepath.Base(path)
+	info := &FileInfo{
+		Name:    strings.TrimSuffix(strings.TrimPrefix(base, cacheFilePrefix), cacheFileSuffix),
+		Path:    path,
+		Size:    stat.Size(),
+		ModTime: stat.ModTime(),
+	}
+
+	fileData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache file: %w", err)
+	}
+
+	var cacheData data
+	if err = json.Unmarshal(fileData, &cacheData); err != nil {
+		// Keep corrupted files visible so they can be cleared
+		return info, nil
+	}
+
+	info.LastUpdated = cacheData.LastUpdated
+	info.Version = cacheData.Version
+	for key, e := range cacheData.Providers {
+		if e == nil {
+			continue
+		}
+		info.Entries = append(info.Entries, EntryInfo{
+			Key:       key,
+			Data:      e.Data,
+			CachedAt:  e.CachedAt,
+			ExpiresAt: e.ExpiresAt,
+		})
+	}
+	slices.SortFunc(info.Entries, func(a, b EntryInfo) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return info, nil
+}
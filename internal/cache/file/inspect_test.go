@@ -0,0 +1,61 @@
+package file
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestInspect(t *testing.T) {
+	dir := t.TempDir()
+
+	c := NewCache(dir, "session")
+	_ = c.Set("b", "value", time.Minute)
+	_ = c.Set("a", 42, time.Minute)
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	f, err := Inspect(dir, "session")
+	if err != nil {
+		t.Fatalf("Inspect() error: %v", err)
+	}
+	if f.Name != "session" || f.Version != "2.0" || f.Size == 0 {
+		t.Errorf("Inspect() = name %q, version %q, size %d", f.Name, f.Version, f.Size)
+	}
+	if len(f.Entries) != 2 || f.Entries[0].Key != "a" || string(f.Entries[0].Data) != "42" {
+		t.Errorf("Inspect() entries = %+v, want a=42 then b", f.Entries)
+	}
+	if f.Entries[0].Expired(time.Now()) || !f.Entries[0].Expired(time.Now().Add(time.Hour)) {
+		t.Error("Expired() wrong relative to ExpiresAt")
+	}
+
+	if _, err = Inspect(dir, "missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Inspect(missing) error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestListAndRemove(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"one", "two"} {
+		c := NewCache(dir, name)
+		_ = c.Set("key", name, time.Minute)
+		if err := c.Save(); err != nil {
+			t.Fatalf("Save() error: %v", err)
+		}
+	}
+
+	files, err := List(dir)
+	if err != nil || len(files) != 2 {
+		t.Fatalf("List() = %d files, %v; want 2", len(files), err)
+	}
+
+	if err = Remove(dir, "one"); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	files, _ = List(dir)
+	if len(files) != 1 || files[0].Name != "two" {
+		t.Errorf("List() after Remove = %+v, want only two", files)
+	}
+}
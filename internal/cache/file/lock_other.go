@@ -0,0 +1,13 @@
+//go:build !unix
+
+package file
+
+// lockFile is a no-op on platforms without flock; saves still merge but may race.
+func lockFile(string) (func(), error) {
+	return func() {}, nil
+}
+
+// tryLockFile always succeeds on platforms without flock.
+func tryLockFile(string) (func(), bool, error) {
+	return func() {}, true, nil
+}
@@ -0,0 +1,77 @@
+//go:build unix
+
+package file
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive advisory lock on path, creating it if needed.
+// Blocks until the lock is available. The returned function releases the lock.
+func lockFile(path string) (func(), error) {
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+
+		if lockedCurrent(f, path) {
+			return unlockFunc(f), nil
+		}
+		// Removed by CleanupLocks while we waited; lock the new file instead
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		_ = f.Close()
+	}
+}
+
+// tryLockFile takes an exclusive advisory lock on path without blocking.
+// Returns false if another process holds the lock.
+func tryLockFile(path string) (func(), bool, error) {
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+			_ = f.Close()
+			if errors.Is(err, syscall.EWOULDBLOCK) {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+
+		if lockedCurrent(f, path) {
+			return unlockFunc(f), true, nil
+		}
+		// Removed by CleanupLocks after we opened it; lock the new file instead
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		_ = f.Close()
+	}
+}
+
+// lockedCurrent reports whether the locked file f is still the one at path.
+// A lock file removed between opening and locking it no longer excludes anyone.
+func lockedCurrent(f *os.File, path string) bool {
+	locked, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	current, err := os.Stat(path)
+	return err == nil && os.SameFile(locked, current)
+}
+
+// unlockFunc returns a function that releases the lock on f and closes it.
+func unlockFunc(f *os.File) func() {
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		_ = f.Close()
+	}
+}
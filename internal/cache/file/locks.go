@@ -0,0 +1,96 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Locks not taken within this duration are removed by CleanupLocks.
+const staleLockAge = 24 * time.Hour
+
+// Name of the marker file in the lock directory whose mtime records the last CleanupLocksIfDue run.
+const lockCleanupMarker = ".ccstatus_lock_cleanup"
+
+// LockDir returns the per-user directory in baseDir that holds TryLock and save lock files,
+// e.g. "ccstatus-locks-1000". Keeping them apart lets any cache backend clean them.
+func LockDir(baseDir string) string {
+	return filepath.Join(baseDir, "ccstatus-locks-"+strconv.Itoa(os.Getuid()))
+}
+
+// saveLockPath returns the lock file held while the cache file for a name is written or removed.
+func saveLockPath(baseDir, name string) string {
+	return filepath.Join(LockDir(baseDir), cacheFileName(name)+".lock")
+}
+
+// TryLock takes a non-blocking lock named key for the cache of a name in baseDir,
+// e.g. to make sure only one process refreshes a provider. Returns false if the lock is held.
+// The lock file lives in LockDir(baseDir); the returned function releases the lock.
+func TryLock(baseDir, name, key string) (func(), bool, error) {
+	dir := LockDir(baseDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, false, err
+	}
+
+	path := filepath.Join(dir, cacheFileName(name)+"."+key+".lock")
+	unlock, ok, err := tryLockFile(path)
+	if ok {
+		// Record the use, so CleanupLocks keeps locks that are still taken regularly
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+	}
+	return unlock, ok, err
+}
+
+// CleanupLocksIfDue runs CleanupLocks if the last run recorded by the marker file in
+// the lock directory is older than interval. Reports whether cleanup ran.
+func CleanupLocksIfDue(baseDir string, interval time.Duration) (bool, error) {
+	dir := LockDir(baseDir)
+	marker := filepath.Join(dir, lockCleanupMarker)
+	now := time.Now()
+
+	if info, err := os.Stat(marker); err == nil && now.Sub(info.ModTime()) < interval {
+		return false, nil
+	}
+
+	// Nothing to clean before the first lock was taken
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return false, nil
+	}
+	if err := os.WriteFile(marker, nil, 0600); err != nil {
+		return false, err
+	}
+	if err := os.Chtimes(marker, now, now); err != nil {
+		return false, err
+	}
+
+	return true, CleanupLocks(baseDir)
+}
+
+// CleanupLocks removes lock files in LockDir(baseDir) not modified for a day.
+// A lock is only removed while this process holds it, so a running holder keeps its file;
+// processes that opened it meanwhile notice the removal once locked and lock a new file.
+func CleanupLocks(baseDir string) error {
+	paths, err := filepath.Glob(filepath.Join(LockDir(baseDir), cacheFileName("*")+"*.lock"))
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-staleLockAge)
+	for _, path := range paths {
+		info, statErr := os.Stat(path)
+		if statErr != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+
+		unlock, ok, lockErr := tryLockFile(path)
+		if lockErr != nil || !ok {
+			continue
+		}
+		_ = os.Remove(path)
+		unlock()
+	}
+
+	return nil
+}
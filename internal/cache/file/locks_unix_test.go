@@ -0,0 +1,141 @@
+//go:build unix
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTryLock_UsesLockDir(t *testing.T) {
+	dir := t.TempDir()
+
+	unlock, ok, err := TryLock(dir, "ws-abc", "prefetch-git")
+	if err != nil || !ok {
+		t.Fatalf("TryLock() = %v, %v", ok, err)
+	}
+	defer unlock()
+
+	if stray, _ := filepath.Glob(filepath.Join(dir, "*.lock")); len(stray) != 0 {
+		t.Errorf("expected no lock files in the cache directory, got %v", stray)
+	}
+	info, err := os.Stat(LockDir(dir))
+	if err != nil {
+		t.Fatalf("lock directory not created: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0700 {
+		t.Errorf("lock directory mode = %o, want 700", perm)
+	}
+}
+
+func TestCleanupLocks(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-2 * staleLockAge)
+
+	// takeLock takes and ages a lock, returning its path
+	takeLock := func(key string) (string, func()) {
+		unlock, ok, err := TryLock(dir, "ws-abc", key)
+		if err != nil || !ok {
+			t.Fatalf("TryLock(%s) = %v, %v", key, ok, err)
+		}
+		path := filepath.Join(LockDir(dir), cacheFileName("ws-abc")+"."+key+".lock")
+		if err = os.Chtimes(path, old, old); err != nil {
+			t.Fatalf("Chtimes() error: %v", err)
+		}
+		return path, unlock
+	}
+
+	stale, unlock := takeLock("prefetch-git")
+	unlock()
+	held, unlockHeld := takeLock("prefetch-usage")
+	defer unlockHeld()
+
+	unlock, _, _ = TryLock(dir, "ws-abc", "prefetch-fresh")
+	unlock()
+
+	if err := CleanupLocks(dir); err != nil {
+		t.Fatalf("CleanupLocks() error: %v", err)
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("expected the stale lock to be removed")
+	}
+	if _, err := os.Stat(held); err != nil {
+		t.Errorf("expected the held lock to be kept: %v", err)
+	}
+	fresh := filepath.Join(LockDir(dir), cacheFileName("ws-abc")+".prefetch-fresh.lock")
+	if _, err := os.Stat(fresh); err != nil {
+		t.Errorf("expected the recently taken lock to be kept: %v", err)
+	}
+}
+
+func TestCleanupLocksIfDue(t *testing.T) {
+	dir := t.TempDir()
+
+	if ran, _ := CleanupLocksIfDue(dir, time.Hour); ran {
+		t.Error("expected no cleanup before any lock was taken")
+	}
+
+	unlock, _, _ := TryLock(dir, "global", "prefetch-git")
+	unlock()
+
+	if ran, err := CleanupLocksIfDue(dir, time.Hour); !ran || err != nil {
+		t.Errorf("first CleanupLocksIfDue() = %v, %v, want true", ran, err)
+	}
+	if ran, _ := CleanupLocksIfDue(dir, time.Hour); ran {
+		t.Error("expected the second run within the interval to be skipped")
+	}
+}
+
+func TestLockFile_RelocksRemovedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+	unlockFirst, err := lockFile(path)
+	if err != nil {
+		t.Fatalf("lockFile() error: %v", err)
+	}
+
+	// Wait for the lock while the holder removes the file, like CleanupLocks
+	locked := make(chan func())
+	go func() {
+		unlock, lockErr := lockFile(path)
+		if lockErr != nil {
+			t.Errorf("lockFile() error: %v", lockErr)
+		}
+		locked <- unlock
+	}()
+	time.Sleep(50 * time.Millisecond)
+	_ = os.Remove(path)
+	unlockFirst()
+
+	unlockSecond := <-locked
+	defer unlockSecond()
+
+	// The waiter holds the file now at path, so nobody else can take it
+	if _, ok, _ := tryLockFile(path); ok {
+		t.Error("tryLockFile() succeeded while the waiter holds the recreated file")
+	}
+}
+
+func TestRemove_KeepsLocks(t *testing.T) {
+	dir := t.TempDir()
+	c := NewCache(dir, "one")
+	_ = c.Set("key", "value", time.Minute)
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	unlock, _, _ := TryLock(dir, "one", "prefetch-git")
+	defer unlock()
+
+	if err := Remove(dir, "one"); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	locks, _ := filepath.Glob(filepath.Join(LockDir(dir), "*.lock"))
+	if len(locks) != 2 {
+		t.Errorf("expected the save and prefetch locks to be kept, got %v", locks)
+	}
+	if stray, _ := filepath.Glob(filepath.Join(dir, "*.lock")); len(stray) != 0 {
+		t.Errorf("expected no lock files next to cache files, got %v", stray)
+	}
+}
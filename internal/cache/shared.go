@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mirage20/ccstatus-go/internal/core"
+)
+
+// Shared hands out one workspace or global cache per name to the stores of many
+// sessions, so a long-running process (the daemon) keeps a single warm copy that
+// every session reads and updates. Session caches stay per store.
+type Shared struct {
+	mu     sync.Mutex
+	caches map[string]*sharedCache // By cache source and name
+}
+
+// sharedCache is a cache shared between stores, with its last use for eviction.
+type sharedCache struct {
+	cache    core.Cache
+	lastUsed time.Time
+}
+
+// NewShared creates an empty set of shared caches.
+func NewShared() *Shared {
+	return &Shared{caches: make(map[string]*sharedCache)}
+}
+
+// get returns the shared cache for key, creating it with newCache on first use.
+func (s *Shared) get(key string, newCache func() core.Cache) core.Cache {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shared, exists := s.caches[key]
+	if !exists {
+		shared = &sharedCache{cache: newCache()}
+		s.caches[key] = shared
+	}
+	shared.lastUsed = time.Now()
+	return shared.cache
+}
+
+// Evict closes and drops the caches no store asked for within idle before now.
+// Stores still holding one keep using it; it's recreated for the next store.
+func (s *Shared) Evict(now time.Time, idle time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, shared := range s.caches {
+		if now.Sub(shared.lastUsed) > idle {
+			_ = shared.cache.Close()
+			delete(s.caches, key)
+		}
+	}
+}
+
+// Close closes all shared caches.
+func (s *Shared) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, shared := range s.caches {
+		_ = shared.cache.Close()
+		delete(s.caches, key)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"path/filepath"
+	"sync"
+
+	"github.com/mirage20/ccstatus-go/internal/cache/file"
+	"github.com/mirage20/ccstatus-go/internal/core"
+)
+
+// Length of the hashed workspace path used in cache names.
+const workspaceHashLength = 16
+
+// Store hands out one cache per scope, creating them lazily.
+type Store struct {
+	sessionID string
+	lockDir   string // Directory for TryLock files; empty disables locking
+	source    string // Backend and directory of the caches, telling shared caches apart
+	shared    *Shared
+	newCache  func(name string) core.Cache
+	caches    map[string]core.Cache
+	onClose   func(names []string)
+	mu        sync.Mutex
+}
+
+// NewStore creates a store that builds caches with newCache.
+// Each cache gets a name unique to its scope (session ID, workspace hash or "global").
+func NewStore(sessionID string, newCache func(name string) core.Cache) *Store {
+	return &Store{
+		sessionID: sessionID,
+		newCache:  newCache,
+		caches:    make(map[string]core.Cache),
+	}
+}
+
+// ForScope returns the cache for a scope, creating it on first use.
+func (s *Store) ForScope(scope core.CacheScope, workspace string) core.Cache {
+	name := s.cacheName(scope, workspace)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Ask the shared caches every time, marking the cache used
+	if s.shared != nil && name != s.sessionID {
+		c := s.shared.get(s.source+"|"+name, func() core.Cache { return s.newCache(name) })
+		s.caches[name] = c
+		return c
+	}
+
+	if c, exists := s.caches[name]; exists {
+		return c
+	}
+
+	c := s.newCache(name)
+	s.caches[name] = c
+	return c
+}
+
+// ShareWith makes the store take its workspace and global caches from shared,
+// so other stores sharing them see this store's entries without reloading.
+func (s *Store) ShareWith(shared *Shared) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.shared = shared
+}
+
+// TryLock takes a non-blocking lock named key for the cache of a scope, so only one
+// process at a time works on it (e.g. refreshes a provider). Returns false if the lock
+// is held elsewhere or caching is disabled. The returned function releases the lock.
+func (s *Store) TryLock(scope core.CacheScope, workspace, key string) (func(), bool) {
+	if s.lockDir == "" {
+		return nil, false
+	}
+
+	unlock, ok, err := file.TryLock(s.lockDir, s.cacheName(scope, workspace), key)
+	if err != nil || !ok {
+		return nil, false
+	}
+	return unlock, true
+}
+
+// OnClose registers fn to run after the caches are closed, with the names of the caches used.
+func (s *Store) OnClose(fn func(names []string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onClose = fn
+}
+
+// Close closes all caches created by the store, then runs the OnClose hook.
+func (s *Store) Close() error {
+	return s.Flush()
+}
+
+// Flush persists pending changes by closing all caches, then runs the OnClose hook.
+// The caches are kept: file, bolt and null caches stay usable after Close, so a
+// long-running process (the daemon) can flush after every request and keep them warm.
+func (s *Store) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	names := make([]string, 0, len(s.caches)+1)
+	names = append(names, s.sessionID)
+	for name, c := range s.caches {
+		if err := c.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		names = append(names, name)
+	}
+
+	if s.onClose != nil {
+		s.onClose(names)
+	}
+	return errors.Join(errs...)
+}
+
+// cacheName returns the cache name for a scope.
+func (s *Store) cacheName(scope core.CacheScope, workspace string) string {
+	switch scope {
+	case core.CacheScopeGlobal:
+		return "global"
+	case core.CacheScopeWorkspace:
+		if workspace == "" {
+			return s.sessionID
+		}
+		// Hash the cleaned path so names are filesystem-safe and fixed length
+		sum := sha256.Sum256([]byte(filepath.Clean(workspace)))
+		return "ws-" + hex.EncodeToString(sum[:])[:workspaceHashLength]
+	case core.CacheScopeSession:
+		return s.sessionID
+	default:
+		return s.sessionID
+	}
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mirage20/ccstatus-go/internal/cache/file"
+	"github.com/mirage20/ccstatus-go/internal/core"
+)
+
+func newFileStore(dir, sessionID string) *Store {
+	return NewStore(sessionID, func(name string) core.Cache {
+		return file.NewCache(dir, name)
+	})
+}
+
+func TestStore_Scopes(t *testing.T) {
+	dir := t.TempDir()
+
+	first := newFileStore(dir, "session-1")
+	first.ForScope(core.CacheScopeSession, "").Set("key", "session", time.Minute)
+	first.ForScope(core.CacheScopeWorkspace, "/repo").Set("key", "workspace", time.Minute)
+	first.ForScope(core.CacheScopeGlobal, "").Set("key", "global", time.Minute)
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	second := newFileStore(dir, "session-2")
+	defer second.Close()
+
+	tests := []struct {
+		name      string
+		scope     core.CacheScope
+		workspace string
+		want      string // Empty means a cache miss
+	}{
+		{name: "session is isolated", scope: core.CacheScopeSession},
+		{name: "same workspace is shared", scope: core.CacheScopeWorkspace, workspace: "/repo", want: "workspace"},
+		{name: "same workspace with unclean path", scope: core.CacheScopeWorkspace, workspace: "/repo/", want: "workspace"},
+		{name: "other workspace is isolated", scope: core.CacheScopeWorkspace, workspace: "/other"},
+		{name: "global is shared", scope: core.CacheScopeGlobal, want: "global"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			second.ForScope(tt.scope, tt.workspace).Get("key", &got)
+			if got != tt.want {
+				t.Errorf("Get() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore_TryLock(t *testing.T) {
+	dir := t.TempDir()
+	first := newFileStore(dir, "session-1")
+	first.lockDir = dir
+	second := newFileStore(dir, "session-2")
+	second.lockDir = dir
+
+	unlock, ok := first.TryLock(core.CacheScopeWorkspace, "/repo", "prefetch-git")
+	if !ok {
+		t.Fatal("TryLock() failed on free lock")
+	}
+
+	// Held for the same workspace, free for another
+	if _, ok = second.TryLock(core.CacheScopeWorkspace, "/repo", "prefetch-git"); ok {
+		t.Error("TryLock() succeeded while held")
+	}
+	if otherUnlock, otherOK := second.TryLock(core.CacheScopeWorkspace, "/other", "prefetch-git"); !otherOK {
+		t.Error("TryLock() failed for another workspace")
+	} else {
+		otherUnlock()
+	}
+
+	unlock()
+	if unlock, ok = second.TryLock(core.CacheScopeWorkspace, "/repo", "prefetch-git"); !ok {
+		t.Error("TryLock() failed after release")
+	} else {
+		unlock()
+	}
+
+	// Caching disabled
+	if _, ok = NewStore("session", nil).TryLock(core.CacheScopeSession, "", "prefetch-git"); ok {
+		t.Error("TryLock() succeeded without a lock directory")
+	}
+}
+
+func TestStore_ShareWith(t *testing.T) {
+	dir := t.TempDir()
+	shared := NewShared()
+	first := newFileStore(dir, "session-1")
+	first.ShareWith(shared)
+	second := newFileStore(dir, "session-2")
+	second.ShareWith(shared)
+
+	// Entries are seen by the other session without saving
+	first.ForScope(core.CacheScopeWorkspace, "/repo").Set("key", "workspace", time.Minute)
+	first.ForScope(core.CacheScopeSession, "").Set("key", "session", time.Minute)
+
+	var got string
+	if found, _ := second.ForScope(core.CacheScopeWorkspace, "/repo").Get("key", &got); !found || got != "workspace" {
+		t.Errorf("shared workspace Get() = %q, %v; want workspace", got, found)
+	}
+	if found, _ := second.ForScope(core.CacheScopeSession, "").Get("key", &got); found {
+		t.Error("session cache was shared")
+	}
+
+	// Evicted caches are saved, then loaded again for the stores
+	shared.Evict(time.Now().Add(time.Second), 0)
+	recreated := second.ForScope(core.CacheScopeWorkspace, "/repo")
+	if recreated != first.ForScope(core.CacheScopeWorkspace, "/repo") {
+		t.Error("stores use different instances after eviction")
+	}
+	if found, _ := recreated.Get("key", &got); !found || got != "workspace" {
+		t.Errorf("Get() after eviction = %q, %v; want workspace", got, found)
+	}
+}
@@ -17,14 +17,52 @@ func init() {
 // Component displays the line changes (added/removed).
 type Component struct {
 	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template     *format.Template
+	addedColor   format.Color
+	removedColor format.Color
+	icon         string // Pre-colored
+	addedSign    string // Pre-colored
+	removedSign  string // Pre-colored
+}
+
+// templateData is the data available to the template; values are pre-colored.
+type templateData struct {
+	Icon        string
+	Added       string
+	Removed     string
+	AddedSign   string
+	RemovedSign string
 }
 
 // New is the factory function for changes component.
 func New(cfgReader *config.Reader) core.Component {
-	cfg := config.GetComponent(cfgReader, "changes", defaultConfig())
-	return &Component{
-		config: cfg,
+	return newComponent(
+		config.GetComponent(cfgReader, "changes", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	addedColor := format.ParseColor(c.config.AddedColor)
+	removedColor := format.ParseColor(c.config.RemovedColor)
+	c.compiled = compiled{
+		template:     format.CompileTemplate(c.config.Template),
+		addedColor:   addedColor,
+		removedColor: removedColor,
+		icon:         format.Colorize(format.ParseColor(c.config.Color), c.icons.ResolveIcons(c.config.Icon)),
+		addedSign:    format.Colorize(addedColor, c.config.AddedSign),
+		removedSign:  format.Colorize(removedColor, c.config.RemovedSign),
 	}
+	return c
 }
 
 // Render generates the changes display string.
@@ -39,22 +77,16 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 		return ""
 	}
 
-	// Parse colors
-	addedColor := format.ParseColor(c.config.AddedColor)
-	removedColor := format.ParseColor(c.config.RemovedColor)
-	componentColor := format.ParseColor(c.config.Color)
-
-	// Build template data with pre-colored values
-	data := map[string]interface{}{
-		"Icon":        format.Colorize(componentColor, c.config.Icon),
-		"Added":       format.Colorize(addedColor, strconv.Itoa(info.Cost.TotalLinesAdded)),
-		"Removed":     format.Colorize(removedColor, strconv.Itoa(info.Cost.TotalLinesRemoved)),
-		"AddedSign":   format.Colorize(addedColor, c.config.AddedSign),
-		"RemovedSign": format.Colorize(removedColor, c.config.RemovedSign),
-	}
+	pre := &c.compiled
 
-	// Render template
-	return format.RenderTemplate(c.config.Template, data)
+	// Render template with pre-colored values
+	return pre.template.Render(templateData{
+		Icon:        pre.icon,
+		Added:       format.Colorize(pre.addedColor, strconv.Itoa(info.Cost.TotalLinesAdded)),
+		Removed:     format.Colorize(pre.removedColor, strconv.Itoa(info.Cost.TotalLinesRemoved)),
+		AddedSign:   pre.addedSign,
+		RemovedSign: pre.removedSign,
+	})
 }
 
 // RequiredProviders returns the list of provider names this component needs.
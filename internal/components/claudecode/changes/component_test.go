@@ -161,7 +161,7 @@ func TestRender(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := &Component{config: tt.config}
+			c := newComponent(tt.config, "")
 			ctx := core.NewRenderContext()
 
 			if tt.sessionInfo != nil {
@@ -178,7 +178,7 @@ func TestRender(t *testing.T) {
 
 // TestRequiredProviders tests that the component declares its dependencies.
 func TestRequiredProviders(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	providers := c.RequiredProviders()
 
 	if len(providers) != 1 || providers[0] != "sessioninfo" {
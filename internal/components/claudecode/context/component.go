@@ -1,9 +1,12 @@
 package context
 
 import (
+	"strings"
+
 	"github.com/mirage20/ccstatus-go/internal/config"
 	"github.com/mirage20/ccstatus-go/internal/core"
 	"github.com/mirage20/ccstatus-go/internal/format"
+	"github.com/mirage20/ccstatus-go/internal/providers/history"
 	"github.com/mirage20/ccstatus-go/internal/providers/sessioninfo"
 )
 
@@ -15,14 +18,52 @@ func init() {
 // Component displays session token usage.
 type Component struct {
 	config *Config
+	icons  format.IconSet
+	locale format.Locale
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template      *format.Template
+	icon          string
+	criticalColor format.Color
+	warningColor  format.Color
+	normalColor   format.Color
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Icon       string
+	Total      int64
+	Formatted  string
+	Percentage float64 // Raw float for template formatting
+	Limit      int64
+	Bar        string
+	Sparkline  string
 }
 
 // New is the factory function for context component.
 func New(cfgReader *config.Reader) core.Component {
-	cfg := config.GetComponent(cfgReader, "context", defaultConfig())
-	return &Component{
-		config: cfg,
+	return newComponent(
+		config.GetComponent(cfgReader, "context", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+		config.GetLocale(cfgReader),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet, locale format.Locale) *Component {
+	c := &Component{config: cfg, icons: icons, locale: locale}
+	c.compiled = compiled{
+		template:      format.CompileTemplate(c.config.Template),
+		icon:          c.icons.ResolveIcons(c.config.Icon),
+		criticalColor: format.ParseColor(c.config.CriticalColor),
+		warningColor:  format.ParseColor(c.config.WarningColor),
+		normalColor:   format.ParseColor(c.config.NormalColor),
 	}
+	return c
 }
 
 // Render generates the token usage display string.
@@ -50,42 +91,59 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 		total = usage.InputTokens + usage.OutputTokens + usage.CacheCreationInputTokens + usage.CacheReadInputTokens
 	}
 
-	// Calculate percentage
-	percentage := float64(total) / float64(contextLimit) * 100
+	// Calculate percentage; without a known limit there is nothing to compare against
+	var percentage float64
+	if contextLimit > 0 {
+		percentage = float64(total) / float64(contextLimit) * 100
+	}
 
 	// Format token count
-	formatted := format.WithUnit(total)
-
-	// Build template data
-	data := map[string]interface{}{
-		"Icon":       c.config.Icon,
-		"Total":      total,
-		"Formatted":  formatted,
-		"Percentage": percentage, // Raw float for template formatting
-		"Limit":      contextLimit,
+	formatted := c.locale.WithUnit(total)
+
+	// Render usage bar (colored with the rest of the output)
+	bar := format.Bar(percentage, c.config.BarWidth, c.icons == format.IconSetASCII)
+
+	// Render usage trend across turns (empty when history is not requested)
+	var sparkline string
+	if h, found := history.GetHistory(ctx); found {
+		sparkline = format.Sparkline(h.ContextPercentages(c.config.ContextLimit), 100, c.icons == format.IconSetASCII)
 	}
 
+	pre := &c.compiled
+
 	// Render template
-	result := format.RenderTemplate(c.config.Template, data)
+	result := pre.template.Render(templateData{
+		Icon:       pre.icon,
+		Total:      total,
+		Formatted:  formatted,
+		Percentage: percentage,
+		Limit:      contextLimit,
+		Bar:        bar,
+		Sparkline:  sparkline,
+	})
 
 	// Determine color based on usage percentage
-	color := c.getUsageColor(percentage)
+	color := c.getUsageColor(pre, percentage)
 	return format.Colorize(color, result)
 }
 
 // RequiredProviders returns the list of provider names this component needs.
+// History is only gathered when the template uses it.
 func (c *Component) RequiredProviders() []string {
+	if strings.Contains(c.config.Template, ".Sparkline") {
+		return []string{"sessioninfo", string(history.Key)}
+	}
 	return []string{"sessioninfo"}
 }
 
 // getUsageColor returns color based on usage percentage and configured thresholds.
-func (c *Component) getUsageColor(percentage float64) format.Color {
+func (c *Component) getUsageColor(pre *compiled, percentage float64) format.Color {
 	switch {
 	case percentage > c.config.CriticalThreshold:
-		return format.ParseColor(c.config.CriticalColor)
+		return pre.criticalColor
 	case percentage > c.config.WarningThreshold:
-		return format.ParseColor(c.config.WarningColor)
+		return pre.warningColor
 	default:
-		return format.ParseColor(c.config.NormalColor)
+		return pre.normalColor
 	}
 }
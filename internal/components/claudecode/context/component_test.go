@@ -4,12 +4,14 @@ import (
 	"testing"
 
 	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
+	"github.com/mirage20/ccstatus-go/internal/providers/history"
 	"github.com/mirage20/ccstatus-go/internal/providers/sessioninfo"
 )
 
 // TestRequiredProviders tests that the component declares its dependencies.
 func TestRequiredProviders(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "", format.Locale{})
 	providers := c.RequiredProviders()
 
 	if len(providers) != 1 || providers[0] != "sessioninfo" {
@@ -278,11 +280,73 @@ func TestRender(t *testing.T) {
 			},
 			want: "\033[33m\uea7b 150k\033[0m", // Yellow color (exactly 75% is not > 75%)
 		},
+		{
+			name: "renders usage bar with eighth-block precision",
+			config: &Config{
+				Template:          "{{.Bar}}",
+				ContextLimit:      200000,
+				BarWidth:          4,
+				WarningThreshold:  60.0,
+				CriticalThreshold: 75.0,
+				NormalColor:       "green",
+				WarningColor:      "yellow",
+				CriticalColor:     "red",
+			},
+			sessionInfo: &sessioninfo.SessionInfo{
+				ContextWindow: core.ContextWindow{
+					ContextWindowSize: 200000,
+					CurrentUsage: &core.ContextUsage{
+						InputTokens: 60000, // 30% of 4 cells = 1.2 cells
+					},
+				},
+			},
+			want: "\033[32m█▎░░\033[0m",
+		},
+		{
+			name: "renders full bar in critical color when over limit",
+			config: &Config{
+				Template:          "{{.Bar}}",
+				ContextLimit:      200000,
+				BarWidth:          4,
+				WarningThreshold:  60.0,
+				CriticalThreshold: 75.0,
+				NormalColor:       "green",
+				WarningColor:      "yellow",
+				CriticalColor:     "red",
+			},
+			sessionInfo: &sessioninfo.SessionInfo{
+				ContextWindow: core.ContextWindow{
+					ContextWindowSize: 200000,
+					CurrentUsage: &core.ContextUsage{
+						InputTokens: 250000, // 125% is clamped to a full bar
+					},
+				},
+			},
+			want: "\033[31m████\033[0m",
+		},
+		{
+			name: "renders empty bar without a context limit",
+			config: &Config{
+				Template:          "{{.Bar}}",
+				BarWidth:          4,
+				WarningThreshold:  60.0,
+				CriticalThreshold: 75.0,
+				NormalColor:       "green",
+				WarningColor:      "yellow",
+				CriticalColor:     "red",
+			},
+			sessionInfo: &sessioninfo.SessionInfo{
+				ContextWindow: core.ContextWindow{
+					CurrentUsage: &core.ContextUsage{InputTokens: 1000},
+				},
+			},
+			want: "\033[32m░░░░\033[0m",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := &Component{config: tt.config}
+			c := newComponent(tt.config, "", format.Locale{})
 			ctx := core.NewRenderContext()
 
 			if tt.sessionInfo != nil {
@@ -296,3 +360,60 @@ func TestRender(t *testing.T) {
 		})
 	}
 }
+
+// TestRenderASCIIBar tests that the ascii icon set renders an ASCII usage bar.
+func TestRenderASCIIBar(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Template = "{{.Bar}}"
+	c := newComponent(cfg, format.IconSetASCII, format.Locale{})
+	ctx := core.NewRenderContext()
+	ctx.Set(sessioninfo.Key, &sessioninfo.SessionInfo{
+		ContextWindow: core.ContextWindow{
+			ContextWindowSize: 200000,
+			CurrentUsage: &core.ContextUsage{
+				InputTokens: 70000, // 35% of 10 cells rounds to 4 cells
+			},
+		},
+	})
+
+	want := "\033[32m####------\033[0m"
+	if got := c.Render(ctx); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+// TestRenderSparkline tests that the usage trend is rendered from history samples.
+func TestRenderSparkline(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Template = "{{.Sparkline}}"
+	c := newComponent(cfg, "", format.Locale{})
+	ctx := core.NewRenderContext()
+	ctx.Set(sessioninfo.Key, &sessioninfo.SessionInfo{
+		ContextWindow: core.ContextWindow{ContextWindowSize: 200000},
+	})
+	ctx.Set(history.Key, &history.History{
+		Samples: []history.Sample{
+			{ContextTokens: 0, ContextWindowSize: 200000},
+			{ContextTokens: 50000, ContextWindowSize: 200000},
+			{ContextTokens: 100000, ContextWindowSize: 200000},
+			{ContextTokens: 200000, ContextWindowSize: 200000},
+		},
+	})
+
+	want := "\033[32m▁▃▅█\033[0m"
+	if got := c.Render(ctx); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+// TestRequiredProvidersWithSparkline tests that history is only required when the template uses it.
+func TestRequiredProvidersWithSparkline(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Template = "{{.Icon}} {{.Formatted}} {{.Sparkline}}"
+	c := newComponent(cfg, "", format.Locale{})
+	providers := c.RequiredProviders()
+
+	if len(providers) != 2 || providers[1] != string(history.Key) {
+		t.Errorf("RequiredProviders() = %v, want [sessioninfo history]", providers)
+	}
+}
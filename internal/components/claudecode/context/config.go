@@ -6,6 +6,8 @@ const (
 	// Default thresholds for usage levels (in percentage).
 	defaultWarningThreshold  = 60.0
 	defaultCriticalThreshold = 75.0
+	// Default width of the usage bar in cells.
+	defaultBarWidth = 10
 )
 
 // Config defines configuration for the context (token usage) component.
@@ -17,15 +19,20 @@ type Config struct {
 	//   {{.Formatted}}  - Formatted token count with unit (e.g. "22k")
 	//   {{.Percentage}} - Usage percentage as float (use {{printf "%.0f" .Percentage}} for whole number)
 	//   {{.Limit}}      - Context limit
+	//   {{.Bar}}        - Usage bar (e.g. "███▌░░░░░░")
+	//   {{.Sparkline}}  - Usage trend across turns (e.g. "▁▂▃▅▇")
 	Template string `yaml:"template"`
 
-	// Icon to display with context
+	// Icon to display with context (glyph or ":name:" icon reference)
 	Icon string `yaml:"icon,omitempty"`
 
 	// Context limit (default 200k for Claude models)
 	// Used as fallback when context_window_size is not available
 	ContextLimit int64 `yaml:"context_limit,omitempty"`
 
+	// Width of the usage bar in cells (0 = no bar)
+	BarWidth int `yaml:"bar_width,omitempty"`
+
 	// Color thresholds (percentages)
 	WarningThreshold  float64 `yaml:"warning_threshold,omitempty"`  // Yellow color threshold
 	CriticalThreshold float64 `yaml:"critical_threshold,omitempty"` // Red color threshold
@@ -40,8 +47,9 @@ type Config struct {
 func defaultConfig() *Config {
 	return &Config{
 		Template:          "{{.Icon}} {{.Formatted}}",
-		Icon:              "\uea7b", // Nerd Font: Context/Tokens icon
+		Icon:              ":context:",
 		ContextLimit:      defaultContextLimit,
+		BarWidth:          defaultBarWidth,
 		WarningThreshold:  defaultWarningThreshold,
 		CriticalThreshold: defaultCriticalThreshold,
 		NormalColor:       "green",
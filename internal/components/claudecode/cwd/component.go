@@ -8,6 +8,7 @@ import (
 	"github.com/mirage20/ccstatus-go/internal/core"
 	"github.com/mirage20/ccstatus-go/internal/format"
 	"github.com/mirage20/ccstatus-go/internal/providers/sessioninfo"
+	"github.com/mirage20/ccstatus-go/internal/text"
 )
 
 func init() {
@@ -18,12 +19,35 @@ func init() {
 type Component struct {
 	config         *Config
 	ignorePatterns []*regexp.Regexp
+	icons          format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template *format.Template
+	color    format.Color
+	icon     string
+	truncate text.Position
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Dir  string
+	Icon string
 }
 
 // New is the factory function for cwd component.
 func New(cfgReader *config.Reader) core.Component {
-	cfg := config.GetComponent(cfgReader, "cwd", defaultConfig())
+	return newComponent(
+		config.GetComponent(cfgReader, "cwd", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
 
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
 	// Pre-compile ignore patterns
 	var patterns []*regexp.Regexp
 	for _, pattern := range cfg.Ignore {
@@ -32,10 +56,14 @@ func New(cfgReader *config.Reader) core.Component {
 		}
 	}
 
-	return &Component{
-		config:         cfg,
-		ignorePatterns: patterns,
+	c := &Component{config: cfg, ignorePatterns: patterns, icons: icons}
+	c.compiled = compiled{
+		template: format.CompileTemplate(c.config.Template),
+		color:    format.ParseColor(c.config.Color),
+		icon:     c.icons.ResolveIcons(c.config.Icon),
+		truncate: text.ParsePosition(c.config.Truncate),
 	}
+	return c
 }
 
 // Render generates the cwd display string.
@@ -59,28 +87,18 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 		}
 	}
 
-	// Truncate from middle if exceeds max length
-	// Keeps prefix and suffix: "my-very-long-directory" → "my-very…ectory"
-	if c.config.MaxLength > 0 && len(dir) > c.config.MaxLength {
-		// Split evenly: first half + … + second half
-		halfLen := (c.config.MaxLength - 1) / 2 //nolint:mnd // split in half
-		firstLen := halfLen
-		lastLen := c.config.MaxLength - 1 - firstLen
-		dir = dir[:firstLen] + "…" + dir[len(dir)-lastLen:]
-	}
-
-	// Build template data
-	data := map[string]any{
-		"Dir":  dir,
-		"Icon": c.config.Icon,
-	}
+	pre := &c.compiled
 
-	// Render template
-	result := format.RenderTemplate(c.config.Template, data)
+	// Truncate if wider than max length (display columns)
+	// Middle keeps prefix and suffix: "my-very-long-directory" → "my-very…ectory"
+	dir = text.Truncate(dir, c.config.MaxLength, pre.truncate)
 
-	// Apply color to the output
-	color := format.ParseColor(c.config.Color)
-	return format.Colorize(color, result)
+	// Render template and apply color to the output
+	result := pre.template.Render(templateData{
+		Dir:  dir,
+		Icon: pre.icon,
+	})
+	return format.Colorize(pre.color, result)
 }
 
 // RequiredProviders returns the list of provider names this component needs.
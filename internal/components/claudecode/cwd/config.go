@@ -1,5 +1,7 @@
 package cwd
 
+import "github.com/mirage20/ccstatus-go/internal/text"
+
 const (
 	// Default max length for directory name truncation.
 	defaultMaxLength = 20
@@ -13,7 +15,7 @@ type Config struct {
 	//   {{.Icon}} - The configured icon
 	Template string `yaml:"template"`
 
-	// Icon to display with directory
+	// Icon to display with directory (glyph or ":name:" icon reference)
 	Icon string `yaml:"icon,omitempty"`
 
 	// Color for the display
@@ -22,18 +24,22 @@ type Config struct {
 	// Ignore patterns (regex) - hide component when directory matches
 	Ignore []string `yaml:"ignore,omitempty"`
 
-	// Maximum length for directory name (0 = no limit)
-	// Truncates from middle with ellipsis: "my-very…ectory"
+	// Maximum length for directory name in display columns (0 = no limit)
+	// Truncates with ellipsis at the Truncate position: "my-very…ectory"
 	MaxLength int `yaml:"max_length,omitempty"`
+
+	// Truncate position: "start", "middle" or "end"
+	Truncate string `yaml:"truncate,omitempty"`
 }
 
 // defaultConfig returns the default configuration for cwd component.
 func defaultConfig() *Config {
 	return &Config{
 		Template:  "{{.Icon}} {{.Dir}}",
-		Icon:      "\uf07b", // Folder icon
+		Icon:      ":folder:",
 		Color:     "gray",
 		Ignore:    []string{},
 		MaxLength: defaultMaxLength,
+		Truncate:  string(text.TruncateMiddle),
 	}
 }
@@ -15,14 +15,49 @@ func init() {
 // Component displays the session and API duration.
 type Component struct {
 	config *Config
+	icons  format.IconSet
+	locale format.Locale
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template *format.Template
+	color    format.Color
+	icon     string
+	apiIcon  string
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Icon          string
+	APIIcon       string
+	TotalDuration string
+	APIDuration   string
+	TotalMs       int64
+	APIMs         int64
 }
 
 // New is the factory function for duration component.
 func New(cfgReader *config.Reader) core.Component {
-	cfg := config.GetComponent(cfgReader, "duration", defaultConfig())
-	return &Component{
-		config: cfg,
+	return newComponent(
+		config.GetComponent(cfgReader, "duration", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+		config.GetLocale(cfgReader),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet, locale format.Locale) *Component {
+	c := &Component{config: cfg, icons: icons, locale: locale}
+	c.compiled = compiled{
+		template: format.CompileTemplate(c.config.Template),
+		color:    format.ParseColor(c.config.Color),
+		icon:     c.icons.ResolveIcons(c.config.Icon),
+		apiIcon:  c.icons.ResolveIcons(c.config.APIIcon),
 	}
+	return c
 }
 
 // Render generates the duration display string.
@@ -38,29 +73,25 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 	}
 
 	// Format durations
-	totalDuration := format.DurationMs(info.Cost.TotalDurationMs)
+	totalDuration := c.locale.DurationMs(info.Cost.TotalDurationMs)
 
 	var apiDuration string
 	if c.config.ShowAPIDuration && info.Cost.TotalAPIDurationMs > 0 {
-		apiDuration = format.DurationMs(info.Cost.TotalAPIDurationMs)
-	}
-
-	// Build template data
-	data := map[string]interface{}{
-		"Icon":          c.config.Icon,
-		"APIIcon":       c.config.APIIcon,
-		"TotalDuration": totalDuration,
-		"APIDuration":   apiDuration,
-		"TotalMs":       info.Cost.TotalDurationMs,
-		"APIMs":         info.Cost.TotalAPIDurationMs,
+		apiDuration = c.locale.DurationMs(info.Cost.TotalAPIDurationMs)
 	}
 
-	// Render template
-	result := format.RenderTemplate(c.config.Template, data)
+	pre := &c.compiled
 
-	// Apply color to the output
-	color := format.ParseColor(c.config.Color)
-	return format.Colorize(color, result)
+	// Render template and apply color to the output
+	result := pre.template.Render(templateData{
+		Icon:          pre.icon,
+		APIIcon:       pre.apiIcon,
+		TotalDuration: totalDuration,
+		APIDuration:   apiDuration,
+		TotalMs:       info.Cost.TotalDurationMs,
+		APIMs:         info.Cost.TotalAPIDurationMs,
+	})
+	return format.Colorize(pre.color, result)
 }
 
 // RequiredProviders returns the list of provider names this component needs.
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
 	"github.com/mirage20/ccstatus-go/internal/providers/sessioninfo"
 )
 
@@ -172,7 +173,7 @@ func TestRender(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := &Component{config: tt.config}
+			c := newComponent(tt.config, "", format.Locale{})
 			ctx := core.NewRenderContext()
 
 			if tt.sessionInfo != nil {
@@ -189,10 +190,26 @@ func TestRender(t *testing.T) {
 
 // TestRequiredProviders tests that the component declares its dependencies.
 func TestRequiredProviders(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "", format.Locale{})
 	providers := c.RequiredProviders()
 
 	if len(providers) != 1 || providers[0] != "sessioninfo" {
 		t.Errorf("RequiredProviders() = %v, want [sessioninfo]", providers)
 	}
 }
+
+// TestRenderLocale tests that duration units follow the locale.
+func TestRenderLocale(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Template = "{{.TotalDuration}}"
+	c := newComponent(cfg, "", format.ParseLocale("fr"))
+	ctx := core.NewRenderContext()
+	ctx.Set(sessioninfo.Key, &sessioninfo.SessionInfo{
+		Cost: core.CostInfo{TotalDurationMs: 5400000}, // 1h30m
+	})
+
+	want := "\033[90m1h30min\033[0m"
+	if got := c.Render(ctx); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
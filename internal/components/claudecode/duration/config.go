@@ -12,7 +12,7 @@ type Config struct {
 	//   {{.APIMs}}         - Raw API duration in milliseconds
 	Template string `yaml:"template"`
 
-	// Icon for total duration
+	// Icon for total duration (glyph or ":name:" icon reference)
 	Icon string `yaml:"icon,omitempty"`
 
 	// Icon for API duration
@@ -29,9 +29,9 @@ type Config struct {
 func defaultConfig() *Config {
 	return &Config{
 		Template:        "{{.Icon}} {{.TotalDuration}}{{if .APIDuration}} {{.APIIcon}} {{.APIDuration}}{{end}}",
-		Icon:            "\uF520",     // Nerd Font: Stopwatch icon
-		APIIcon:         "\U000F1616", // Nerd Font: Connected plug icon
-		Color:           "gray",       // Dimmed/gray color
+		Icon:            ":stopwatch:",
+		APIIcon:         ":api:",
+		Color:           "gray", // Dimmed/gray color
 		ShowAPIDuration: true,
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/mirage20/ccstatus-go/internal/core"
 	"github.com/mirage20/ccstatus-go/internal/format"
 	"github.com/mirage20/ccstatus-go/internal/providers/sessioninfo"
+	"github.com/mirage20/ccstatus-go/internal/text"
 )
 
 const (
@@ -22,14 +23,51 @@ func init() {
 // Component displays the Claude model information.
 type Component struct {
 	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template *format.Template
+	icons    map[string]string       // Pattern → resolved icon
+	colors   map[string]format.Color // Pattern → parsed color
+	fallback format.Color            // Color when no pattern matches
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	ID        string
+	Name      string
+	ShortName string
+	Icon      string
 }
 
 // New is the factory function for model component.
 func New(cfgReader *config.Reader) core.Component {
-	cfg := config.GetComponent(cfgReader, "model", defaultConfig())
-	return &Component{
-		config: cfg,
+	return newComponent(
+		config.GetComponent(cfgReader, "model", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	c.compiled = compiled{
+		template: format.CompileTemplate(c.config.Template),
+		icons:    make(map[string]string, len(c.config.Icons)),
+		colors:   make(map[string]format.Color, len(c.config.Colors)),
+		fallback: format.ParseColor(""),
+	}
+	for pattern, icon := range c.config.Icons {
+		c.compiled.icons[pattern] = c.icons.ResolveIcons(icon)
 	}
+	for pattern, color := range c.config.Colors {
+		c.compiled.colors[pattern] = format.ParseColor(color)
+	}
+	return c
 }
 
 // Render generates the model display string.
@@ -39,20 +77,18 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 		return ""
 	}
 
-	// Build template data
-	data := map[string]interface{}{
-		"ID":        sessionInfo.Model.ID,
-		"Name":      sessionInfo.Model.DisplayName,
-		"ShortName": c.getShortName(sessionInfo),
-		"Icon":      c.getIcon(sessionInfo.Model.ID),
-	}
+	pre := &c.compiled
 
 	// Render template
-	result := format.RenderTemplate(c.config.Template, data)
-
-	// Apply color to the output
-	colorName := c.getColorName(sessionInfo.Model.ID)
-	color := format.ParseColor(colorName) // Always returns a valid color (gray if unknown)
+	result := pre.template.Render(templateData{
+		ID:        sessionInfo.Model.ID,
+		Name:      sessionInfo.Model.DisplayName,
+		ShortName: c.getShortName(sessionInfo),
+		Icon:      matchPattern(sessionInfo.Model.ID, pre.icons, ""),
+	})
+
+	// Apply color to the output (gray if no pattern matches)
+	color := matchPattern(sessionInfo.Model.ID, pre.colors, pre.fallback)
 	return format.Colorize(color, result)
 }
 
@@ -76,30 +112,20 @@ func (c *Component) getShortName(info *sessioninfo.SessionInfo) string {
 		return "Haiku"
 	default:
 		// If unknown, return a shortened version
-		if len(displayName) > maxDisplayNameLength {
+		if text.Width(displayName) > maxDisplayNameLength {
 			return "Claude"
 		}
 		return displayName
 	}
 }
 
-// matchPattern finds the first pattern that matches the modelID.
-func (c *Component) matchPattern(modelID string, patterns map[string]string) string {
+// matchPattern returns the value of the first pattern that matches the modelID, or fallback.
+func matchPattern[V any](modelID string, patterns map[string]V, fallback V) V {
 	modelLower := strings.ToLower(modelID)
 	for pattern, value := range patterns {
 		if strings.Contains(modelLower, strings.ToLower(pattern)) {
 			return value
 		}
 	}
-	return "" // No match found
-}
-
-// getIcon returns the icon for the model based on config patterns.
-func (c *Component) getIcon(modelID string) string {
-	return c.matchPattern(modelID, c.config.Icons)
-}
-
-// getColorName returns the color name for the model based on config patterns.
-func (c *Component) getColorName(modelID string) string {
-	return c.matchPattern(modelID, c.config.Colors)
+	return fallback
 }
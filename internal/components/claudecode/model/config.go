@@ -12,6 +12,7 @@ type Config struct {
 
 	// Visual customization (pattern -> value)
 	// Pattern matching is done via substring search on model ID
+	// Icon values may be glyphs or ":name:" icon references
 	Icons  map[string]string `yaml:"icons,omitempty"`
 	Colors map[string]string `yaml:"colors,omitempty"`
 }
@@ -21,9 +22,9 @@ func defaultConfig() *Config {
 	return &Config{
 		Template: "{{.Icon}} {{.ShortName}}",
 		Icons: map[string]string{
-			"opus":   "\uf2db", // Nerd Font: Microchip icon
-			"sonnet": "\uf2db", // Nerd Font: Microchip icon
-			"haiku":  "\uf2db", // Nerd Font: Microchip icon
+			"opus":   ":model:",
+			"sonnet": ":model:",
+			"haiku":  ":model:",
 		},
 		Colors: map[string]string{
 			"opus":   "magenta",
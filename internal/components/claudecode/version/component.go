@@ -15,14 +15,41 @@ func init() {
 // Component displays the Claude Code version.
 type Component struct {
 	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template *format.Template
+	color    format.Color
+	icon     string
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Version string
+	Icon    string
 }
 
 // New is the factory function for version component.
 func New(cfgReader *config.Reader) core.Component {
-	cfg := config.GetComponent(cfgReader, "version", defaultConfig())
-	return &Component{
-		config: cfg,
+	return newComponent(
+		config.GetComponent(cfgReader, "version", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	c.compiled = compiled{
+		template: format.CompileTemplate(c.config.Template),
+		color:    format.ParseColor(c.config.Color),
+		icon:     c.icons.ResolveIcons(c.config.Icon),
 	}
+	return c
 }
 
 // Render generates the version display string.
@@ -36,18 +63,14 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 		return ""
 	}
 
-	// Build template data
-	data := map[string]interface{}{
-		"Version": info.Version,
-		"Icon":    c.config.Icon,
-	}
-
-	// Render template
-	result := format.RenderTemplate(c.config.Template, data)
+	pre := &c.compiled
 
-	// Apply color to the output
-	color := format.ParseColor(c.config.Color)
-	return format.Colorize(color, result)
+	// Render template and apply color to the output
+	result := pre.template.Render(templateData{
+		Version: info.Version,
+		Icon:    pre.icon,
+	})
+	return format.Colorize(pre.color, result)
 }
 
 // RequiredProviders returns the list of provider names this component needs.
@@ -5,6 +5,7 @@ import (
 	"github.com/mirage20/ccstatus-go/internal/core"
 	"github.com/mirage20/ccstatus-go/internal/format"
 	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+	"github.com/mirage20/ccstatus-go/internal/text"
 )
 
 func init() {
@@ -14,14 +15,43 @@ func init() {
 // Component displays the git branch name.
 type Component struct {
 	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template *format.Template
+	color    format.Color
+	icon     string
+	truncate text.Position
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Icon   string
+	Branch string
 }
 
 // New is the factory function for git.branch component.
 func New(cfgReader *config.Reader) core.Component {
-	cfg := config.GetComponent(cfgReader, "git.branch", defaultConfig())
-	return &Component{
-		config: cfg,
+	return newComponent(
+		config.GetComponent(cfgReader, "git.branch", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	c.compiled = compiled{
+		template: format.CompileTemplate(c.config.Template),
+		color:    format.ParseColor(c.config.Color),
+		icon:     c.icons.ResolveIcons(c.config.Icon),
+		truncate: text.ParsePosition(c.config.Truncate),
 	}
+	return c
 }
 
 // Render generates the git branch display string.
@@ -31,40 +61,20 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 		return ""
 	}
 
-	branch := info.Branch
+	pre := &c.compiled
 
-	// Truncate from middle if exceeds max length
-	if c.config.MaxLength > 0 && len(branch) > c.config.MaxLength {
-		branch = truncateMiddle(branch, c.config.MaxLength)
-	}
-
-	// Build template data
-	data := map[string]any{
-		"Icon":   c.config.Icon,
-		"Branch": branch,
-	}
-
-	// Render template
-	result := format.RenderTemplate(c.config.Template, data)
+	// Truncate if wider than max length (display columns)
+	branch := text.Truncate(info.Branch, c.config.MaxLength, pre.truncate)
 
-	// Apply color
-	color := format.ParseColor(c.config.Color)
-	return format.Colorize(color, result)
+	// Render template and apply color
+	result := pre.template.Render(templateData{
+		Icon:   pre.icon,
+		Branch: branch,
+	})
+	return format.Colorize(pre.color, result)
 }
 
 // RequiredProviders returns the list of provider names this component needs.
 func (c *Component) RequiredProviders() []string {
 	return []string{"git"}
 }
-
-// truncateMiddle truncates a string from the middle with ellipsis.
-func truncateMiddle(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	// Split in half, accounting for ellipsis
-	halfLen := (maxLen - 1) / 2 //nolint:mnd // split in half minus ellipsis
-	firstLen := halfLen
-	lastLen := maxLen - 1 - firstLen
-	return s[:firstLen] + "…" + s[len(s)-lastLen:]
-}
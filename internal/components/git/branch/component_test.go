@@ -4,11 +4,12 @@ import (
 	"testing"
 
 	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
 	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
 )
 
 func TestComponent_Render_NotARepo(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	// No git info in context
@@ -20,7 +21,7 @@ func TestComponent_Render_NotARepo(t *testing.T) {
 }
 
 func TestComponent_Render_NotARepo_EmptyInfo(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	// Git info with IsRepo = false
@@ -34,7 +35,7 @@ func TestComponent_Render_NotARepo_EmptyInfo(t *testing.T) {
 }
 
 func TestComponent_Render_Branch(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -55,7 +56,7 @@ func TestComponent_Render_Branch(t *testing.T) {
 }
 
 func TestComponent_Render_DetachedHead(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -73,7 +74,7 @@ func TestComponent_Render_DetachedHead(t *testing.T) {
 func TestComponent_Render_Truncation(t *testing.T) {
 	cfg := defaultConfig()
 	cfg.MaxLength = 10
-	c := &Component{config: cfg}
+	c := newComponent(cfg, "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -93,29 +94,80 @@ func TestComponent_Render_Truncation(t *testing.T) {
 	}
 }
 
-func TestTruncateMiddle(t *testing.T) {
+func TestComponent_Render_IconSets(t *testing.T) {
 	tests := []struct {
-		input    string
-		maxLen   int
-		expected string
+		icons format.IconSet
+		want  string
 	}{
-		{"short", 10, "short"},
-		{"feature/long-branch", 10, "feat…ranch"},
-		{"abcdefghij", 5, "ab…ij"},
-		{"abcdefghij", 10, "abcdefghij"},
+		{format.IconSetNerdFont, "\ue725 main"},
+		{format.IconSetEmoji, "🌿 main"},
+		{format.IconSetASCII, "git main"},
 	}
 
 	for _, tc := range tests {
-		result := truncateMiddle(tc.input, tc.maxLen)
-		if result != tc.expected {
-			t.Errorf("truncateMiddle(%q, %d) = %q, want %q",
-				tc.input, tc.maxLen, result, tc.expected)
+		c := newComponent(defaultConfig(), tc.icons)
+		ctx := core.NewRenderContext()
+		ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, Branch: "main"})
+
+		result := c.Render(ctx)
+		if !containsText(result, tc.want) {
+			t.Errorf("icons=%s: expected result to contain %q, got %q", tc.icons, tc.want, result)
 		}
 	}
 }
 
+func TestComponent_Render_LiteralIcon(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Icon = "⎇"
+	c := newComponent(cfg, format.IconSetASCII)
+	ctx := core.NewRenderContext()
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, Branch: "main"})
+
+	result := c.Render(ctx)
+	if !containsText(result, "⎇ main") {
+		t.Errorf("expected literal icon to be kept, got %q", result)
+	}
+}
+
+func TestComponent_Render_TruncationMultibyte(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.MaxLength = 9
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo: true,
+		Branch: "機能/ログイン画面",
+	})
+
+	result := c.Render(ctx)
+
+	if !containsText(result, "機能…画面") {
+		t.Errorf("expected branch truncated on character boundaries, got %q", result)
+	}
+}
+
+func TestComponent_Render_TruncateStart(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.MaxLength = 10
+	cfg.Truncate = "start"
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo: true,
+		Branch: "feature/long-branch",
+	})
+
+	result := c.Render(ctx)
+
+	if !containsText(result, "…ng-branch") {
+		t.Errorf("expected branch truncated from start, got %q", result)
+	}
+}
+
 func TestComponent_RequiredProviders(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	providers := c.RequiredProviders()
 
 	if len(providers) != 1 || providers[0] != "git" {
@@ -1,5 +1,7 @@
 package branch
 
+import "github.com/mirage20/ccstatus-go/internal/text"
+
 const (
 	// Default max length for branch name truncation.
 	defaultMaxLength = 20
@@ -13,23 +15,27 @@ type Config struct {
 	//   {{.Branch}} - Branch name or @hash for detached HEAD
 	Template string `yaml:"template"`
 
-	// Icon to display.
+	// Icon to display (glyph or ":name:" icon reference).
 	Icon string `yaml:"icon,omitempty"`
 
 	// Color for the display.
 	Color string `yaml:"color,omitempty"`
 
-	// MaxLength for branch name (0 = no limit).
-	// Truncates from middle with ellipsis.
+	// MaxLength for branch name in display columns (0 = no limit).
+	// Truncates with ellipsis at the Truncate position.
 	MaxLength int `yaml:"max_length,omitempty"`
+
+	// Truncate position: "start", "middle" or "end".
+	Truncate string `yaml:"truncate,omitempty"`
 }
 
 // defaultConfig returns the default configuration.
 func defaultConfig() *Config {
 	return &Config{
 		Template:  "{{.Icon}} {{.Branch}}",
-		Icon:      "\uE725", // Git branch nerd font icon
+		Icon:      ":git_branch:",
 		Color:     "gray",
 		MaxLength: defaultMaxLength,
+		Truncate:  string(text.TruncateMiddle),
 	}
 }
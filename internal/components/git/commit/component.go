@@ -0,0 +1,94 @@
+package commit
+
+import (
+	"time"
+
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func init() {
+	core.RegisterComponent("git.commit", New)
+}
+
+// Component displays the HEAD commit.
+type Component struct {
+	config *Config
+	icons  format.IconSet
+	locale format.Locale
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template    *format.Template
+	color       format.Color
+	recentColor format.Color
+	icon        string
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Icon    string
+	Hash    string
+	Subject string
+	Author  string
+	Age     string
+	Time    time.Time
+}
+
+// New is the factory function for git.commit component.
+func New(cfgReader *config.Reader) core.Component {
+	return newComponent(
+		config.GetComponent(cfgReader, "git.commit", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+		config.GetLocale(cfgReader),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet, locale format.Locale) *Component {
+	c := &Component{config: cfg, icons: icons, locale: locale}
+	c.compiled = compiled{
+		template:    format.CompileTemplate(c.config.Template),
+		color:       format.ParseColor(c.config.Color),
+		recentColor: format.ParseColor(c.config.RecentColor),
+		icon:        c.icons.ResolveIcons(c.config.Icon),
+	}
+	return c
+}
+
+// Render generates the commit display string.
+func (c *Component) Render(ctx *core.RenderContext) string {
+	info, ok := gitprovider.GetInfo(ctx)
+	if !ok || !info.IsRepo || info.CommitHash == "" {
+		return ""
+	}
+
+	pre := &c.compiled
+	age := time.Since(info.CommitTime)
+
+	// Render template and apply color
+	result := pre.template.Render(templateData{
+		Icon:    pre.icon,
+		Hash:    info.CommitHash,
+		Subject: info.CommitSubject,
+		Author:  info.CommitAuthor,
+		Age:     c.locale.DurationDays(int(age.Minutes())),
+		Time:    info.CommitTime,
+	})
+
+	// Highlight commits made moments ago, e.g. by Claude
+	if age < c.config.RecentAge {
+		return format.Colorize(pre.recentColor, result)
+	}
+	return format.Colorize(pre.color, result)
+}
+
+// RequiredProviders returns the list of provider names this component needs.
+func (c *Component) RequiredProviders() []string {
+	return []string{"git"}
+}
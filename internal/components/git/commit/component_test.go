@@ -0,0 +1,79 @@
+package commit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func TestComponent_Render_NotARepo(t *testing.T) {
+	c := newComponent(defaultConfig(), "", format.Locale{})
+	ctx := core.NewRenderContext()
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string for non-repo, got %q", result)
+	}
+}
+
+func TestComponent_Render_NoCommits(t *testing.T) {
+	c := newComponent(defaultConfig(), "", format.Locale{})
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, Branch: "main"})
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string before the first commit, got %q", result)
+	}
+}
+
+func TestComponent_Render(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Icon = "C"
+	c := newComponent(cfg, "", format.Locale{})
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:        true,
+		CommitHash:    "abc1234",
+		CommitSubject: "Add a rather long commit subject that needs truncation",
+		CommitAuthor:  "Test User",
+		CommitTime:    time.Now().Add(-3*time.Hour - 20*time.Minute),
+	})
+
+	result := c.Render(ctx)
+
+	if want := "C abc1234 Add a rather long commit subj… (3h20m)"; !strings.Contains(result, want) {
+		t.Errorf("expected result to contain %q, got %q", want, result)
+	}
+	if !strings.HasPrefix(result, string(format.ColorGray)) {
+		t.Errorf("expected older commit in gray, got %q", result)
+	}
+}
+
+func TestComponent_Render_Recent(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Template = "{{.Author}}: {{.Subject}} {{.Age}}"
+	c := newComponent(cfg, "", format.Locale{})
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:        true,
+		CommitHash:    "abc1234",
+		CommitSubject: "Fix bug",
+		CommitAuthor:  "Claude",
+		CommitTime:    time.Now().Add(-time.Minute),
+	})
+
+	result := c.Render(ctx)
+
+	if !strings.Contains(result, "Claude: Fix bug 1m") {
+		t.Errorf("expected result to contain 'Claude: Fix bug 1m', got %q", result)
+	}
+	if !strings.HasPrefix(result, string(format.ColorGreen)) {
+		t.Errorf("expected recent commit in green, got %q", result)
+	}
+}
@@ -0,0 +1,45 @@
+package commit
+
+import "time"
+
+const (
+	// Default age below which a commit counts as recent.
+	defaultRecentAge = 5 * time.Minute
+)
+
+// Config defines configuration for the git.commit component.
+type Config struct {
+	// Template for display.
+	// Available variables:
+	//   {{.Icon}}    - The configured icon
+	//   {{.Hash}}    - Abbreviated commit hash
+	//   {{.Subject}} - First line of the commit message
+	//   {{.Author}}  - Author name
+	//   {{.Age}}     - Time since the commit, e.g. "5m", "3h20m", "2d4h"
+	//   {{.Time}}    - Commit time (time.Time)
+	// Use {{.Subject | truncate 30}} to limit long subjects.
+	Template string `yaml:"template"`
+
+	// Icon to display (glyph or ":name:" icon reference).
+	Icon string `yaml:"icon,omitempty"`
+
+	// Color for the display.
+	Color string `yaml:"color,omitempty"`
+
+	// RecentAge is the age below which a commit is shown in RecentColor (0 = never).
+	RecentAge time.Duration `yaml:"recent_age,omitempty"`
+
+	// RecentColor for commits younger than RecentAge.
+	RecentColor string `yaml:"recent_color,omitempty"`
+}
+
+// defaultConfig returns the default configuration.
+func defaultConfig() *Config {
+	return &Config{
+		Template:    "{{.Icon}} {{.Hash}} {{.Subject | truncate 30}} ({{.Age}})",
+		Icon:        ":git_commit:",
+		Color:       "gray",
+		RecentAge:   defaultRecentAge,
+		RecentColor: "green",
+	}
+}
@@ -0,0 +1,129 @@
+package diff
+
+import (
+	"strconv"
+
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func init() {
+	core.RegisterComponent("git.diff", New)
+}
+
+// Component displays the size of the pending change: files and lines in the git diff.
+type Component struct {
+	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template     *format.Template
+	color        format.Color
+	addedColor   format.Color
+	removedColor format.Color
+	icon         string // Pre-colored
+	addedSign    string // Pre-colored
+	removedSign  string // Pre-colored
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Icon        string
+	Files       string
+	Insertions  string
+	Deletions   string
+	AddedSign   string
+	RemovedSign string
+	Staged      gitprovider.DiffStat
+	Unstaged    gitprovider.DiffStat
+}
+
+// New is the factory function for git.diff component.
+func New(cfgReader *config.Reader) core.Component {
+	return newComponent(
+		config.GetComponent(cfgReader, "git.diff", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	color := format.ParseColor(c.config.Color)
+	addedColor := format.ParseColor(c.config.AddedColor)
+	removedColor := format.ParseColor(c.config.RemovedColor)
+	c.compiled = compiled{
+		template:     format.CompileTemplate(c.config.Template),
+		color:        color,
+		addedColor:   addedColor,
+		removedColor: removedColor,
+		icon:         format.Colorize(color, c.icons.ResolveIcons(c.config.Icon)),
+		addedSign:    format.Colorize(addedColor, c.config.AddedSign),
+		removedSign:  format.Colorize(removedColor, c.config.RemovedSign),
+	}
+	return c
+}
+
+// Render generates the diff display string.
+func (c *Component) Render(ctx *core.RenderContext) string {
+	info, ok := gitprovider.GetInfo(ctx)
+	if !ok || !info.IsRepo {
+		return ""
+	}
+
+	// Timed out stats are unknown rather than zero
+	if info.TimedOut.Has(gitprovider.QueryDiff) {
+		return c.renderUnknown()
+	}
+
+	staged, unstaged := info.StagedDiff, info.UnstagedDiff
+	files := info.DiffFiles
+
+	// Skip without changes unless ShowZero is set
+	if !c.config.ShowZero && files == 0 {
+		return ""
+	}
+
+	pre := &c.compiled
+
+	// Render template with pre-colored values
+	return pre.template.Render(templateData{
+		Icon:        pre.icon,
+		Files:       format.Colorize(pre.color, strconv.Itoa(files)),
+		Insertions:  format.Colorize(pre.addedColor, strconv.Itoa(staged.Insertions+unstaged.Insertions)),
+		Deletions:   format.Colorize(pre.removedColor, strconv.Itoa(staged.Deletions+unstaged.Deletions)),
+		AddedSign:   pre.addedSign,
+		RemovedSign: pre.removedSign,
+		Staged:      staged,
+		Unstaged:    unstaged,
+	})
+}
+
+// renderUnknown renders the template with the unknown text in place of the numbers.
+// Returns empty string if no unknown text is configured.
+func (c *Component) renderUnknown() string {
+	if c.config.Unknown == "" {
+		return ""
+	}
+
+	pre := &c.compiled
+	return pre.template.Render(templateData{
+		Icon:        pre.icon,
+		Files:       format.Colorize(pre.color, c.config.Unknown),
+		Insertions:  format.Colorize(pre.addedColor, c.config.Unknown),
+		Deletions:   format.Colorize(pre.removedColor, c.config.Unknown),
+		AddedSign:   pre.addedSign,
+		RemovedSign: pre.removedSign,
+	})
+}
+
+// RequiredProviders returns the list of provider names this component needs.
+func (c *Component) RequiredProviders() []string {
+	return []string{"git"}
+}
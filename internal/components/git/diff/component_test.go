@@ -0,0 +1,111 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mirage20/ccstatus-go/internal/core"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func TestComponent_Render_NotARepo(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string for non-repo, got %q", result)
+	}
+}
+
+func TestComponent_Render_NoChanges(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, Branch: "main"})
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string without changes, got %q", result)
+	}
+}
+
+func TestComponent_Render_ShowZero(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Template = "{{.Files}}/{{.Insertions}}/{{.Deletions}}"
+	cfg.ShowZero = true
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, Branch: "main"})
+
+	if result := c.Render(ctx); !strings.Contains(result, "0") {
+		t.Errorf("expected zero counts with show_zero, got %q", result)
+	}
+}
+
+func TestComponent_Render(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Icon = "D"
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	// One of the files has both staged and unstaged changes
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:       true,
+		StagedDiff:   gitprovider.DiffStat{Files: 1, Insertions: 10},
+		UnstagedDiff: gitprovider.DiffStat{Files: 2, Insertions: 5, Deletions: 3},
+		DiffFiles:    2,
+	})
+
+	result := c.Render(ctx)
+
+	// Totals are pre-colored: files in the component color, lines in added/removed colors
+	for _, want := range []string{"D", "\033[90m2", "+\033[0m\033[32m15", "-\033[0m\033[31m3"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected result to contain %q, got %q", want, result)
+		}
+	}
+}
+
+func TestComponent_Render_StagedAndUnstaged(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Template = "S{{.Staged.Files}}+{{.Staged.Insertions}}-{{.Staged.Deletions}} " +
+		"U{{.Unstaged.Files}}+{{.Unstaged.Insertions}}-{{.Unstaged.Deletions}}"
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:       true,
+		StagedDiff:   gitprovider.DiffStat{Files: 1, Insertions: 10},
+		UnstagedDiff: gitprovider.DiffStat{Files: 2, Insertions: 5, Deletions: 3},
+		DiffFiles:    2,
+	})
+
+	if result, want := c.Render(ctx), "S1+10-0 U2+5-3"; result != want {
+		t.Errorf("expected %q, got %q", want, result)
+	}
+}
+
+func TestComponent_Render_TimedOut(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:   true,
+		TimedOut: gitprovider.QueryDiff,
+	})
+
+	// Each number is replaced, in its own color
+	result := c.Render(ctx)
+	for _, want := range []string{"\033[90m?", "+\033[0m\033[32m?", "-\033[0m\033[31m?"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected result to contain %q, got %q", want, result)
+		}
+	}
+
+	cfg := defaultConfig()
+	cfg.Unknown = ""
+	c = newComponent(cfg, "")
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string with unknown disabled, got %q", result)
+	}
+}
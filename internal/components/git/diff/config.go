@@ -0,0 +1,53 @@
+package diff
+
+// Config defines configuration for the git.diff component.
+type Config struct {
+	// Display template
+	// Available template parameters:
+	//   {{.Icon}}        - The configured icon (uses component color)
+	//   {{.Files}}       - Changed files, staged or unstaged (uses component color)
+	//   {{.Insertions}}  - Lines added, staged plus unstaged (uses added color)
+	//   {{.Deletions}}   - Lines removed, staged plus unstaged (uses removed color)
+	//   {{.AddedSign}}   - The configured added sign (uses added color)
+	//   {{.RemovedSign}} - The configured removed sign (uses removed color)
+	//   {{.Staged}}      - Staged stats, uncolored: {{.Staged.Files}}, {{.Staged.Insertions}}, {{.Staged.Deletions}}
+	//   {{.Unstaged}}    - Unstaged stats, uncolored, with the same fields
+	// A file with both staged and unstaged changes counts once in {{.Files}}.
+	// Untracked files aren't part of the diff.
+	Template string `yaml:"template"`
+
+	// Icon to display (glyph or ":name:" icon reference)
+	Icon string `yaml:"icon,omitempty"`
+
+	// Color for the icon and file count
+	Color string `yaml:"color,omitempty"`
+
+	// Signs/prefixes for added and removed lines
+	AddedSign   string `yaml:"added_sign,omitempty"`
+	RemovedSign string `yaml:"removed_sign,omitempty"`
+
+	// Colors for added/removed lines
+	AddedColor   string `yaml:"added_color,omitempty"`
+	RemovedColor string `yaml:"removed_color,omitempty"`
+
+	// Whether to show the component without pending changes
+	ShowZero bool `yaml:"show_zero,omitempty"`
+
+	// Shown in place of each number when git diff timed out (empty hides the component)
+	Unknown string `yaml:"unknown,omitempty"`
+}
+
+// defaultConfig returns the default configuration.
+func defaultConfig() *Config {
+	return &Config{
+		Template:     "{{.Icon}} {{.Files}} {{.AddedSign}}{{.Insertions}} {{.RemovedSign}}{{.Deletions}}",
+		Icon:         ":git_diff:",
+		Color:        "gray",
+		AddedSign:    "+",
+		RemovedSign:  "-",
+		AddedColor:   "green",
+		RemovedColor: "red",
+		ShowZero:     false, // Don't show component without changes
+		Unknown:      "?",
+	}
+}
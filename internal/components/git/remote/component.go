@@ -0,0 +1,94 @@
+package remote
+
+import (
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func init() {
+	core.RegisterComponent("git.remote", New)
+}
+
+// Component displays the forge and repository of the remote.
+type Component struct {
+	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template   *format.Template
+	color      format.Color
+	forgeIcons map[string]string // Resolved
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Icon  string
+	Forge string
+	Host  string
+	Owner string
+	Repo  string
+	Slug  string
+	URL   string
+}
+
+// New is the factory function for git.remote component.
+func New(cfgReader *config.Reader) core.Component {
+	return newComponent(
+		config.GetComponent(cfgReader, "git.remote", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	forgeIcons := make(map[string]string, len(c.config.ForgeIcons))
+	for forge, icon := range c.config.ForgeIcons {
+		forgeIcons[forge] = c.icons.ResolveIcons(icon)
+	}
+	c.compiled = compiled{
+		template:   format.CompileTemplate(c.config.Template),
+		color:      format.ParseColor(c.config.Color),
+		forgeIcons: forgeIcons,
+	}
+	return c
+}
+
+// Render generates the remote display string.
+func (c *Component) Render(ctx *core.RenderContext) string {
+	info, ok := gitprovider.GetInfo(ctx)
+	if !ok || !info.IsRepo || info.RemoteRepo == "" {
+		return ""
+	}
+
+	pre := &c.compiled
+
+	slug := info.RemoteRepo
+	if info.RemoteOwner != "" {
+		slug = info.RemoteOwner + "/" + info.RemoteRepo
+	}
+
+	// Render template and apply color
+	result := pre.template.Render(templateData{
+		Icon:  pre.forgeIcons[info.Forge],
+		Forge: info.Forge,
+		Host:  info.RemoteHost,
+		Owner: info.RemoteOwner,
+		Repo:  info.RemoteRepo,
+		Slug:  slug,
+		URL:   info.RemoteURL,
+	})
+
+	return format.Colorize(pre.color, result)
+}
+
+// RequiredProviders returns the list of provider names this component needs.
+func (c *Component) RequiredProviders() []string {
+	return []string{"git"}
+}
@@ -0,0 +1,94 @@
+package remote
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func TestComponent_Render_NotARepo(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string for non-repo, got %q", result)
+	}
+}
+
+func TestComponent_Render_NoRemote(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	// Local path remotes have no repository to show
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, Branch: "main", RemoteURL: "/srv/git/project.git"})
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string without a hosted remote, got %q", result)
+	}
+}
+
+func TestComponent_Render(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.ForgeIcons = map[string]string{gitprovider.ForgeGitHub: "GH", gitprovider.ForgeGitLab: "GL"}
+	c := newComponent(cfg, "")
+
+	tests := []struct {
+		name string
+		info gitprovider.Info
+		want string
+	}{
+		{
+			name: "github",
+			info: gitprovider.Info{Forge: gitprovider.ForgeGitHub, RemoteOwner: "mirage20", RemoteRepo: "ccstatus-go"},
+			want: "GH mirage20/ccstatus-go",
+		},
+		{
+			name: "gitlab subgroup",
+			info: gitprovider.Info{Forge: gitprovider.ForgeGitLab, RemoteOwner: "group/sub", RemoteRepo: "project"},
+			want: "GL group/sub/project",
+		},
+		{
+			name: "no owner",
+			info: gitprovider.Info{Forge: gitprovider.ForgeGitHub, RemoteRepo: "project"},
+			want: "GH project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := core.NewRenderContext()
+			tt.info.IsRepo = true
+			ctx.Set(gitprovider.Key, &tt.info)
+
+			result := c.Render(ctx)
+			if !strings.Contains(result, tt.want) {
+				t.Errorf("expected result to contain %q, got %q", tt.want, result)
+			}
+			if !strings.HasPrefix(result, string(format.ColorBlue)) {
+				t.Errorf("expected blue, got %q", result)
+			}
+		})
+	}
+}
+
+func TestComponent_Render_Template(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Template = "{{.Forge}}@{{.Host}}:{{.Owner}}|{{.Repo}}"
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:      true,
+		Forge:       gitprovider.ForgeSelfHosted,
+		RemoteHost:  "git.example.com",
+		RemoteOwner: "tools",
+		RemoteRepo:  "builder",
+	})
+
+	if result, want := c.Render(ctx), "self-hosted@git.example.com:tools|builder"; !strings.Contains(result, want) {
+		t.Errorf("expected result to contain %q, got %q", want, result)
+	}
+}
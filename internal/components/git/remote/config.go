@@ -0,0 +1,39 @@
+package remote
+
+import gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+
+// Config defines configuration for the git.remote component.
+// Shows the remote named by providers.git.remote, origin by default.
+type Config struct {
+	// Template for display.
+	// Available variables:
+	//   {{.Icon}}  - Icon of the forge (from ForgeIcons)
+	//   {{.Forge}} - Forge name: github, gitlab, bitbucket, gitea or self-hosted
+	//   {{.Host}}  - Remote host name
+	//   {{.Owner}} - User, organization or group path owning the repository
+	//   {{.Repo}}  - Repository name
+	//   {{.Slug}}  - "owner/repo", or just the repository name without an owner
+	//   {{.URL}}   - Remote URL, without credentials
+	Template string `yaml:"template"`
+
+	// ForgeIcons for each forge (forge name -> glyph or ":name:" icon reference).
+	ForgeIcons map[string]string `yaml:"forge_icons,omitempty"`
+
+	// Color for the display.
+	Color string `yaml:"color,omitempty"`
+}
+
+// defaultConfig returns the default configuration.
+func defaultConfig() *Config {
+	return &Config{
+		Template: "{{.Icon}} {{.Slug}}",
+		ForgeIcons: map[string]string{
+			gitprovider.ForgeGitHub:     ":git_github:",
+			gitprovider.ForgeGitLab:     ":git_gitlab:",
+			gitprovider.ForgeBitbucket:  ":git_bitbucket:",
+			gitprovider.ForgeGitea:      ":git_gitea:",
+			gitprovider.ForgeSelfHosted: ":git_remote:",
+		},
+		Color: "blue",
+	}
+}
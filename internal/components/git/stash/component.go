@@ -16,14 +16,42 @@ func init() {
 // Component displays git stash count.
 type Component struct {
 	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template *format.Template
+	color    format.Color
+	icon     string
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Icon  string
+	Count int
+	Text  string // Count, or the unknown text if it timed out
 }
 
 // New is the factory function for git.stash component.
 func New(cfgReader *config.Reader) core.Component {
-	cfg := config.GetComponent(cfgReader, "git.stash", defaultConfig())
-	return &Component{
-		config: cfg,
+	return newComponent(
+		config.GetComponent(cfgReader, "git.stash", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	c.compiled = compiled{
+		template: format.CompileTemplate(c.config.Template),
+		color:    format.ParseColor(c.config.Color),
+		icon:     c.icons.ResolveIcons(c.config.Icon),
 	}
+	return c
 }
 
 // Render generates the git stash display string.
@@ -33,23 +61,28 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 		return ""
 	}
 
-	// If no stashes, return empty
-	if info.Stash == 0 {
+	// A timed out count is unknown rather than zero
+	text := strconv.Itoa(info.Stash)
+	switch {
+	case info.TimedOut.Has(gitprovider.QueryStash):
+		if c.config.Unknown == "" {
+			return ""
+		}
+		text = c.config.Unknown
+	case info.Stash == 0:
+		// If no stashes, return empty
 		return ""
 	}
 
-	// Build template data
-	data := map[string]any{
-		"Icon":  c.config.Icon,
-		"Count": strconv.Itoa(info.Stash),
-	}
-
-	// Render template
-	result := format.RenderTemplate(c.config.Template, data)
+	pre := &c.compiled
 
-	// Apply color
-	color := format.ParseColor(c.config.Color)
-	return format.Colorize(color, result)
+	// Render template and apply color
+	result := pre.template.Render(templateData{
+		Icon:  pre.icon,
+		Count: info.Stash,
+		Text:  text,
+	})
+	return format.Colorize(pre.color, result)
 }
 
 // RequiredProviders returns the list of provider names this component needs.
@@ -8,7 +8,7 @@ import (
 )
 
 func TestComponent_Render_NotARepo(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	result := c.Render(ctx)
@@ -19,7 +19,7 @@ func TestComponent_Render_NotARepo(t *testing.T) {
 }
 
 func TestComponent_Render_NoStash(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -35,7 +35,7 @@ func TestComponent_Render_NoStash(t *testing.T) {
 }
 
 func TestComponent_Render_WithStash(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -56,7 +56,7 @@ func TestComponent_Render_WithStash(t *testing.T) {
 func TestComponent_Render_CustomIcon(t *testing.T) {
 	cfg := defaultConfig()
 	cfg.Icon = "S"
-	c := &Component{config: cfg}
+	c := newComponent(cfg, "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -74,8 +74,46 @@ func TestComponent_Render_CustomIcon(t *testing.T) {
 	}
 }
 
+func TestComponent_Render_TimedOut(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:   true,
+		TimedOut: gitprovider.QueryStatus | gitprovider.QueryStash,
+	})
+
+	if result := c.Render(ctx); !containsText(result, "?") {
+		t.Errorf("expected result to contain '?', got %q", result)
+	}
+
+	cfg := defaultConfig()
+	cfg.Unknown = ""
+	c = newComponent(cfg, "")
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string with unknown disabled, got %q", result)
+	}
+}
+
+func TestComponent_Render_NumericCount(t *testing.T) {
+	// Templates can compare the count as a number
+	cfg := defaultConfig()
+	cfg.Template = "{{if gt .Count 1}}many{{else}}one{{end}} {{printf \"%d\" .Count}}"
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo: true,
+		Stash:  2,
+	})
+
+	if result := c.Render(ctx); !containsText(result, "many 2") {
+		t.Errorf("expected result to contain 'many 2', got %q", result)
+	}
+}
+
 func TestComponent_RequiredProviders(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	providers := c.RequiredProviders()
 
 	if len(providers) != 1 || providers[0] != "git" {
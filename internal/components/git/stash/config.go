@@ -5,21 +5,26 @@ type Config struct {
 	// Template for display.
 	// Available variables:
 	//   {{.Icon}}  - The configured icon
-	//   {{.Count}} - Number of stash entries
+	//   {{.Count}} - Number of stash entries (0 if git status timed out)
+	//   {{.Text}}  - Number of stash entries, or Unknown if git status timed out
 	Template string `yaml:"template"`
 
-	// Icon for stash.
+	// Icon for stash (glyph or ":name:" icon reference).
 	Icon string `yaml:"icon,omitempty"`
 
 	// Color for display.
 	Color string `yaml:"color,omitempty"`
+
+	// Shown as {{.Text}} when git status timed out (empty hides the component)
+	Unknown string `yaml:"unknown,omitempty"`
 }
 
 // defaultConfig returns the default configuration.
 func defaultConfig() *Config {
 	return &Config{
-		Template: "{{.Icon}} {{.Count}}",
-		Icon:     "\uf48d", // nf-fa-inbox
+		Template: "{{.Icon}} {{.Text}}",
+		Icon:     ":git_stash:",
 		Color:    "cyan",
+		Unknown:  "?",
 	}
 }
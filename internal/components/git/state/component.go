@@ -0,0 +1,88 @@
+package state
+
+import (
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func init() {
+	core.RegisterComponent("git.state", New)
+}
+
+// Component displays the repository operation in progress, such as a rebase or merge.
+type Component struct {
+	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template *format.Template
+	color    format.Color
+	icon     string
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Icon   string
+	State  string
+	Name   string
+	Branch string
+	Step   int
+	Total  int
+}
+
+// New is the factory function for git.state component.
+func New(cfgReader *config.Reader) core.Component {
+	return newComponent(
+		config.GetComponent(cfgReader, "git.state", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	c.compiled = compiled{
+		template: format.CompileTemplate(c.config.Template),
+		color:    format.ParseColor(c.config.Color),
+		icon:     c.icons.ResolveIcons(c.config.Icon),
+	}
+	return c
+}
+
+// Render generates the operation state display string.
+func (c *Component) Render(ctx *core.RenderContext) string {
+	info, ok := gitprovider.GetInfo(ctx)
+	if !ok || !info.IsRepo || info.State == "" {
+		return ""
+	}
+
+	pre := &c.compiled
+
+	// Fall back to the operation name if no label is configured
+	label, ok := c.config.Labels[info.State]
+	if !ok {
+		label = info.State
+	}
+
+	// Render template and apply color
+	result := pre.template.Render(templateData{
+		Icon:   pre.icon,
+		State:  label,
+		Name:   info.State,
+		Branch: info.RebaseBranch,
+		Step:   info.StateStep,
+		Total:  info.StateTotal,
+	})
+	return format.Colorize(pre.color, result)
+}
+
+// RequiredProviders returns the list of provider names this component needs.
+func (c *Component) RequiredProviders() []string {
+	return []string{"git"}
+}
@@ -0,0 +1,82 @@
+package state
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mirage20/ccstatus-go/internal/core"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func TestComponent_Render_NotARepo(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string for non-repo, got %q", result)
+	}
+}
+
+func TestComponent_Render_NoOperation(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, Branch: "main"})
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string without operation in progress, got %q", result)
+	}
+}
+
+func TestComponent_Render_Rebase(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Icon = "R"
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:       true,
+		Branch:       "@abc1234",
+		State:        gitprovider.StateRebase,
+		StateStep:    2,
+		StateTotal:   5,
+		RebaseBranch: "feature",
+	})
+
+	result := c.Render(ctx)
+
+	if !strings.Contains(result, "R REBASE feature 2/5") {
+		t.Errorf("expected result to contain 'R REBASE feature 2/5', got %q", result)
+	}
+}
+
+func TestComponent_Render_Merge(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Icon = "M"
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, Branch: "main", State: gitprovider.StateMerge})
+
+	result := c.Render(ctx)
+
+	if !strings.Contains(result, "M MERGING") {
+		t.Errorf("expected result to contain 'M MERGING', got %q", result)
+	}
+	if strings.Contains(result, "/") {
+		t.Errorf("expected no step counter for a merge, got %q", result)
+	}
+}
+
+func TestComponent_Render_UnlabeledState(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Labels = nil
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, State: gitprovider.StateBisect})
+
+	if result := c.Render(ctx); !strings.Contains(result, "bisect") {
+		t.Errorf("expected result to fall back to the operation name, got %q", result)
+	}
+}
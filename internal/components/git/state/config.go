@@ -0,0 +1,42 @@
+package state
+
+import gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+
+// Config defines configuration for the git.state component.
+type Config struct {
+	// Template for display.
+	// Available variables:
+	//   {{.Icon}}   - The configured icon
+	//   {{.State}}  - Label of the operation in progress (from Labels)
+	//   {{.Name}}   - Operation name: rebase, am, merge, cherry-pick, revert or bisect
+	//   {{.Branch}} - Branch being rebased (empty otherwise)
+	//   {{.Step}}   - Current rebase or am step (0 if not applicable)
+	//   {{.Total}}  - Number of rebase or am steps (0 if not applicable)
+	Template string `yaml:"template"`
+
+	// Icon to display (glyph or ":name:" icon reference).
+	Icon string `yaml:"icon,omitempty"`
+
+	// Color for the display.
+	Color string `yaml:"color,omitempty"`
+
+	// Labels for each operation (operation name -> label).
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+// defaultConfig returns the default configuration.
+func defaultConfig() *Config {
+	return &Config{
+		Template: "{{.Icon}} {{.State}}{{if .Branch}} {{.Branch}}{{end}}{{if .Total}} {{.Step}}/{{.Total}}{{end}}",
+		Icon:     ":git_state:",
+		Color:    "magenta",
+		Labels: map[string]string{
+			gitprovider.StateRebase:     "REBASE",
+			gitprovider.StateAm:         "AM",
+			gitprovider.StateMerge:      "MERGING",
+			gitprovider.StateCherryPick: "CHERRY-PICKING",
+			gitprovider.StateRevert:     "REVERTING",
+			gitprovider.StateBisect:     "BISECTING",
+		},
+	}
+}
@@ -1,7 +1,7 @@
 package status
 
 import (
-	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/mirage20/ccstatus-go/internal/config"
@@ -17,14 +17,62 @@ func init() {
 // Component displays git working tree status (staged, modified, untracked).
 type Component struct {
 	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template  *format.Template
+	staged    indicator
+	modified  indicator
+	untracked indicator
+	conflicts indicator
+	unknown   string // Pre-colored
+}
+
+// indicator is a resolved icon with its color.
+type indicator struct {
+	icon  string
+	color format.Color
+}
+
+// templateData is the data available to the template; indicators are pre-colored.
+type templateData struct {
+	Staged    string
+	Modified  string
+	Untracked string
+	Conflicts string
+	Index     gitprovider.ChangeCounts
+	Worktree  gitprovider.ChangeCounts
 }
 
 // New is the factory function for git.status component.
 func New(cfgReader *config.Reader) core.Component {
-	cfg := config.GetComponent(cfgReader, "git.status", defaultConfig())
-	return &Component{
-		config: cfg,
+	return newComponent(
+		config.GetComponent(cfgReader, "git.status", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	c.compiled = compiled{
+		template:  format.CompileTemplate(c.config.Template),
+		staged:    c.indicator(c.config.StagedIcon, c.config.StagedColor),
+		modified:  c.indicator(c.config.ModifiedIcon, c.config.ModifiedColor),
+		untracked: c.indicator(c.config.UntrackedIcon, c.config.UntrackedColor),
+		conflicts: c.indicator(c.config.ConflictIcon, c.config.ConflictColor),
+		unknown:   format.Colorize(format.ParseColor(c.config.UnknownColor), c.config.Unknown),
 	}
+	return c
+}
+
+// indicator resolves an icon and color from the config.
+func (c *Component) indicator(icon, color string) indicator {
+	return indicator{icon: c.icons.ResolveIcons(icon), color: format.ParseColor(color)}
 }
 
 // Render generates the git status display string.
@@ -34,33 +82,30 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 		return ""
 	}
 
+	// Timed out counts are unknown rather than zero; don't pass them off as a clean tree
+	if info.TimedOut.Has(gitprovider.QueryStatus) {
+		if c.config.Unknown == "" {
+			return ""
+		}
+		return c.compiled.unknown
+	}
+
 	// If all counts are zero, return empty (clean working tree)
 	if info.Staged == 0 && info.Modified == 0 && info.Untracked == 0 && info.Conflicts == 0 {
 		return ""
 	}
 
-	// Parse colors
-	stagedColor := format.ParseColor(c.config.StagedColor)
-	modifiedColor := format.ParseColor(c.config.ModifiedColor)
-	untrackedColor := format.ParseColor(c.config.UntrackedColor)
-	conflictColor := format.ParseColor(c.config.ConflictColor)
-
-	// Build pre-colored indicators
-	staged := c.formatCount(info.Staged, c.config.StagedIcon, stagedColor)
-	modified := c.formatCount(info.Modified, c.config.ModifiedIcon, modifiedColor)
-	untracked := c.formatCount(info.Untracked, c.config.UntrackedIcon, untrackedColor)
-	conflicts := c.formatCount(info.Conflicts, c.config.ConflictIcon, conflictColor)
-
-	// Build template data with pre-colored values
-	data := map[string]any{
-		"Staged":    staged,
-		"Modified":  modified,
-		"Untracked": untracked,
-		"Conflicts": conflicts,
-	}
+	pre := &c.compiled
 
 	// Render template (values are pre-colored) and trim leading space
-	result := format.RenderTemplate(c.config.Template, data)
+	result := pre.template.Render(templateData{
+		Staged:    c.formatCount(info.Staged, pre.staged.icon, pre.staged.color),
+		Modified:  c.formatCount(info.Modified, pre.modified.icon, pre.modified.color),
+		Untracked: c.formatCount(info.Untracked, pre.untracked.icon, pre.untracked.color),
+		Conflicts: c.formatCount(info.Conflicts, pre.conflicts.icon, pre.conflicts.color),
+		Index:     info.IndexChanges,
+		Worktree:  info.WorktreeChanges,
+	})
 	return strings.TrimLeft(result, " ")
 }
 
@@ -70,9 +115,10 @@ func (c *Component) RequiredProviders() []string {
 }
 
 // formatCount returns colorized "icon+count" if count > 0, empty string otherwise.
+// The icon must already be resolved.
 func (c *Component) formatCount(count int, icon string, color format.Color) string {
 	if count == 0 {
 		return ""
 	}
-	return format.Colorize(color, fmt.Sprintf("%s%d", icon, count))
+	return format.Colorize(color, icon+strconv.Itoa(count))
 }
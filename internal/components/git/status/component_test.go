@@ -9,7 +9,7 @@ import (
 )
 
 func TestComponent_Render_NotARepo(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	result := c.Render(ctx)
@@ -20,7 +20,7 @@ func TestComponent_Render_NotARepo(t *testing.T) {
 }
 
 func TestComponent_Render_CleanWorkingTree(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -38,7 +38,7 @@ func TestComponent_Render_CleanWorkingTree(t *testing.T) {
 }
 
 func TestComponent_Render_StagedOnly(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -54,7 +54,7 @@ func TestComponent_Render_StagedOnly(t *testing.T) {
 }
 
 func TestComponent_Render_ModifiedOnly(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -70,7 +70,7 @@ func TestComponent_Render_ModifiedOnly(t *testing.T) {
 }
 
 func TestComponent_Render_UntrackedOnly(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -86,7 +86,7 @@ func TestComponent_Render_UntrackedOnly(t *testing.T) {
 }
 
 func TestComponent_Render_AllStatuses(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -112,7 +112,7 @@ func TestComponent_Render_AllStatuses(t *testing.T) {
 }
 
 func TestComponent_Render_ConflictsOnly(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -130,13 +130,55 @@ func TestComponent_Render_ConflictsOnly(t *testing.T) {
 	}
 }
 
+func TestComponent_Render_ChangeKinds(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Template = "+{{.Index.Added}} R{{.Index.Renamed}} ~{{.Worktree.Modified}} -{{.Worktree.Deleted}} T{{.Worktree.TypeChanged}}"
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:          true,
+		Staged:          3,
+		Modified:        4,
+		IndexChanges:    gitprovider.ChangeCounts{Added: 2, Renamed: 1},
+		WorktreeChanges: gitprovider.ChangeCounts{Modified: 2, Deleted: 1, TypeChanged: 1},
+	})
+
+	if result, want := c.Render(ctx), "+2 R1 ~2 -1 T1"; result != want {
+		t.Errorf("expected %q, got %q", want, result)
+	}
+}
+
+func TestComponent_Render_TimedOut(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	// Counts of a timed out status are zero but unknown, not a clean tree
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:   true,
+		Branch:   "main",
+		TimedOut: gitprovider.QueryStatus | gitprovider.QueryUpstream,
+	})
+
+	if result := c.Render(ctx); !containsText(result, "?") {
+		t.Errorf("expected result to contain '?', got %q", result)
+	}
+
+	cfg := defaultConfig()
+	cfg.Unknown = ""
+	c = newComponent(cfg, "")
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string with unknown disabled, got %q", result)
+	}
+}
+
 func TestComponent_Render_CustomIcons(t *testing.T) {
 	cfg := defaultConfig()
 	cfg.StagedIcon = "S"
 	cfg.ModifiedIcon = "M"
 	cfg.UntrackedIcon = "U"
 	cfg.ConflictIcon = "C"
-	c := &Component{config: cfg}
+	c := newComponent(cfg, "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -163,8 +205,29 @@ func TestComponent_Render_CustomIcons(t *testing.T) {
 	}
 }
 
+func TestComponent_Render_ASCIIIcons(t *testing.T) {
+	c := newComponent(defaultConfig(), format.IconSetASCII)
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:    true,
+		Staged:    1,
+		Modified:  2,
+		Untracked: 3,
+		Conflicts: 4,
+	})
+
+	result := c.Render(ctx)
+
+	for _, want := range []string{"+ 1", "~ 2", "? 3", "! 4"} {
+		if !containsText(result, want) {
+			t.Errorf("expected result to contain %q, got %q", want, result)
+		}
+	}
+}
+
 func TestComponent_RequiredProviders(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	providers := c.RequiredProviders()
 
 	if len(providers) != 1 || providers[0] != "git" {
@@ -173,7 +236,7 @@ func TestComponent_RequiredProviders(t *testing.T) {
 }
 
 func TestFormatCount(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	testColor := format.ParseColor("green")
 
 	// Test zero count returns empty
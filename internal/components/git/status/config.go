@@ -3,14 +3,18 @@ package status
 // Config defines configuration for the git.status component.
 type Config struct {
 	// Template for display.
-	// Available variables (all pre-colored):
+	// Available variables (pre-colored):
 	//   {{.Staged}}    - Staged files indicator (e.g., " 3")
 	//   {{.Modified}}  - Modified files indicator (e.g., " 2")
 	//   {{.Untracked}} - Untracked files indicator (e.g., " 1")
 	//   {{.Conflicts}} - Conflicted files indicator (e.g., " 2")
+	// Breakdowns by kind of change (uncolored counts):
+	//   {{.Index}}     - Staged changes: {{.Index.Added}}, {{.Index.Modified}}, {{.Index.Deleted}},
+	//                    {{.Index.Renamed}}, {{.Index.TypeChanged}}
+	//   {{.Worktree}}  - Unstaged changes, with the same fields
 	Template string `yaml:"template"`
 
-	// Icons/prefixes for each status type.
+	// Icons/prefixes for each status type (glyphs or ":name:" icon references).
 	StagedIcon    string `yaml:"staged_icon,omitempty"`
 	ModifiedIcon  string `yaml:"modified_icon,omitempty"`
 	UntrackedIcon string `yaml:"untracked_icon,omitempty"`
@@ -21,19 +25,25 @@ type Config struct {
 	ModifiedColor  string `yaml:"modified_color,omitempty"`
 	UntrackedColor string `yaml:"untracked_color,omitempty"`
 	ConflictColor  string `yaml:"conflict_color,omitempty"`
+
+	// Shown instead of the counts when git status timed out (empty hides the component)
+	Unknown      string `yaml:"unknown,omitempty"`
+	UnknownColor string `yaml:"unknown_color,omitempty"`
 }
 
 // defaultConfig returns the default configuration.
 func defaultConfig() *Config {
 	return &Config{
 		Template:       "{{if .Staged}} {{.Staged}}{{end}}{{if .Modified}} {{.Modified}}{{end}}{{if .Untracked}} {{.Untracked}}{{end}}{{if .Conflicts}} {{.Conflicts}}{{end}}",
-		StagedIcon:     "\uf05d ", // nf-cod-diff_added
-		ModifiedIcon:   "\uf044 ", // nf-fa-pencil
-		UntrackedIcon:  "\uf420 ", // nf-fa-question
-		ConflictIcon:   "\uf421 ", // nf-fa-exclamation
+		StagedIcon:     ":git_staged: ",
+		ModifiedIcon:   ":git_modified: ",
+		UntrackedIcon:  ":git_untracked: ",
+		ConflictIcon:   ":git_conflict: ",
 		StagedColor:    "green",
 		ModifiedColor:  "yellow",
 		UntrackedColor: "gray",
 		ConflictColor:  "red",
+		Unknown:        "?",
+		UnknownColor:   "gray",
 	}
 }
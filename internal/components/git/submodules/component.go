@@ -0,0 +1,88 @@
+package submodules
+
+import (
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func init() {
+	core.RegisterComponent("git.submodules", New)
+}
+
+// Component displays a summary of the repository's submodules.
+type Component struct {
+	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template     *format.Template
+	color        format.Color
+	changedColor format.Color
+	icon         string
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Icon      string
+	Count     int
+	Dirty     int
+	OutOfDate int
+}
+
+// New is the factory function for git.submodules component.
+func New(cfgReader *config.Reader) core.Component {
+	return newComponent(
+		config.GetComponent(cfgReader, "git.submodules", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	c.compiled = compiled{
+		template:     format.CompileTemplate(c.config.Template),
+		color:        format.ParseColor(c.config.Color),
+		changedColor: format.ParseColor(c.config.ChangedColor),
+		icon:         c.icons.ResolveIcons(c.config.Icon),
+	}
+	return c
+}
+
+// Render generates the submodule summary display string.
+func (c *Component) Render(ctx *core.RenderContext) string {
+	info, ok := gitprovider.GetInfo(ctx)
+	if !ok || !info.IsRepo || info.Submodules == 0 {
+		return ""
+	}
+
+	changed := info.SubmodulesDirty > 0 || info.SubmodulesOutOfDate > 0
+	if !changed && c.config.HideClean {
+		return ""
+	}
+
+	pre := &c.compiled
+
+	// Render template and apply color
+	result := pre.template.Render(templateData{
+		Icon:      pre.icon,
+		Count:     info.Submodules,
+		Dirty:     info.SubmodulesDirty,
+		OutOfDate: info.SubmodulesOutOfDate,
+	})
+	if changed {
+		return format.Colorize(pre.changedColor, result)
+	}
+	return format.Colorize(pre.color, result)
+}
+
+// RequiredProviders returns the list of provider names this component needs.
+func (c *Component) RequiredProviders() []string {
+	return []string{"git"}
+}
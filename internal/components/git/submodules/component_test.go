@@ -0,0 +1,68 @@
+package submodules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func TestComponent_Render_NoSubmodules(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true})
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string without submodules, got %q", result)
+	}
+}
+
+func TestComponent_Render_Clean(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Icon = "S"
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, Submodules: 3})
+
+	result := c.Render(ctx)
+
+	if !strings.Contains(result, "S 3") {
+		t.Errorf("expected result to contain 'S 3', got %q", result)
+	}
+	if !strings.HasPrefix(result, string(format.ColorGray)) {
+		t.Errorf("expected clean submodules in gray, got %q", result)
+	}
+
+	cfg.HideClean = true
+	if result = c.Render(ctx); result != "" {
+		t.Errorf("expected clean submodules to be hidden with hide_clean, got %q", result)
+	}
+}
+
+func TestComponent_Render_Changed(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Icon = "S"
+	cfg.HideClean = true
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:              true,
+		Submodules:          3,
+		SubmodulesDirty:     1,
+		SubmodulesOutOfDate: 2,
+	})
+
+	result := c.Render(ctx)
+
+	if !strings.Contains(result, "S 3 ~2 *1") {
+		t.Errorf("expected result to contain 'S 3 ~2 *1', got %q", result)
+	}
+	if !strings.HasPrefix(result, string(format.ColorYellow)) {
+		t.Errorf("expected changed submodules in yellow, got %q", result)
+	}
+}
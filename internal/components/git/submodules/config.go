@@ -0,0 +1,34 @@
+package submodules
+
+// Config defines configuration for the git.submodules component.
+type Config struct {
+	// Template for display.
+	// Available variables:
+	//   {{.Icon}}      - The configured icon
+	//   {{.Count}}     - Number of submodules
+	//   {{.Dirty}}     - Submodules with modified or untracked content
+	//   {{.OutOfDate}} - Submodules with a different commit checked out than recorded
+	Template string `yaml:"template"`
+
+	// Icon to display (glyph or ":name:" icon reference).
+	Icon string `yaml:"icon,omitempty"`
+
+	// Color when all submodules are clean.
+	Color string `yaml:"color,omitempty"`
+
+	// Color when any submodule is dirty or out of date.
+	ChangedColor string `yaml:"changed_color,omitempty"`
+
+	// HideClean hides the component while all submodules are clean.
+	HideClean bool `yaml:"hide_clean,omitempty"`
+}
+
+// defaultConfig returns the default configuration.
+func defaultConfig() *Config {
+	return &Config{
+		Template:     "{{.Icon}} {{.Count}}{{if .OutOfDate}} ~{{.OutOfDate}}{{end}}{{if .Dirty}} *{{.Dirty}}{{end}}",
+		Icon:         ":git_submodule:",
+		Color:        "gray",
+		ChangedColor: "yellow",
+	}
+}
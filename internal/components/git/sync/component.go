@@ -1,7 +1,7 @@
 package sync
 
 import (
-	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/mirage20/ccstatus-go/internal/config"
@@ -17,14 +17,49 @@ func init() {
 // Component displays git sync status (ahead/behind upstream).
 type Component struct {
 	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template *format.Template
+	ahead    indicator
+	behind   indicator
+	unknown  string // Pre-colored
+}
+
+// indicator is a resolved icon with its color.
+type indicator struct {
+	icon  string
+	color format.Color
+}
+
+// templateData is the data available to the template; values are pre-colored.
+type templateData struct {
+	Ahead  string
+	Behind string
 }
 
 // New is the factory function for git.sync component.
 func New(cfgReader *config.Reader) core.Component {
-	cfg := config.GetComponent(cfgReader, "git.sync", defaultConfig())
-	return &Component{
-		config: cfg,
+	return newComponent(
+		config.GetComponent(cfgReader, "git.sync", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	c.compiled = compiled{
+		template: format.CompileTemplate(c.config.Template),
+		ahead:    indicator{icon: c.icons.ResolveIcons(c.config.AheadIcon), color: format.ParseColor(c.config.AheadColor)},
+		behind:   indicator{icon: c.icons.ResolveIcons(c.config.BehindIcon), color: format.ParseColor(c.config.BehindColor)},
+		unknown:  format.Colorize(format.ParseColor(c.config.UnknownColor), c.config.Unknown),
 	}
+	return c
 }
 
 // Render generates the git sync display string.
@@ -34,6 +69,14 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 		return ""
 	}
 
+	// Timed out counts are unknown rather than zero; don't pass them off as in sync
+	if info.TimedOut.Has(gitprovider.QueryUpstream) {
+		if c.config.Unknown == "" {
+			return ""
+		}
+		return c.compiled.unknown
+	}
+
 	// If no upstream configured, return empty
 	if !info.HasUpstream {
 		return ""
@@ -44,22 +87,13 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 		return ""
 	}
 
-	// Parse colors
-	aheadColor := format.ParseColor(c.config.AheadColor)
-	behindColor := format.ParseColor(c.config.BehindColor)
-
-	// Build pre-colored indicators
-	ahead := c.formatCount(info.Ahead, c.config.AheadIcon, aheadColor)
-	behind := c.formatCount(info.Behind, c.config.BehindIcon, behindColor)
-
-	// Build template data with pre-colored values
-	data := map[string]any{
-		"Ahead":  ahead,
-		"Behind": behind,
-	}
+	pre := &c.compiled
 
-	// Render template and trim leading space
-	result := format.RenderTemplate(c.config.Template, data)
+	// Render template (values are pre-colored) and trim leading space
+	result := pre.template.Render(templateData{
+		Ahead:  c.formatCount(info.Ahead, pre.ahead.icon, pre.ahead.color),
+		Behind: c.formatCount(info.Behind, pre.behind.icon, pre.behind.color),
+	})
 	return strings.TrimLeft(result, " ")
 }
 
@@ -69,9 +103,10 @@ func (c *Component) RequiredProviders() []string {
 }
 
 // formatCount returns colorized "icon+count" if count > 0, empty string otherwise.
+// The icon must already be resolved.
 func (c *Component) formatCount(count int, icon string, color format.Color) string {
 	if count == 0 {
 		return ""
 	}
-	return format.Colorize(color, fmt.Sprintf("%s%d", icon, count))
+	return format.Colorize(color, icon+strconv.Itoa(count))
 }
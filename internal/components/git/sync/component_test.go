@@ -9,7 +9,7 @@ import (
 )
 
 func TestComponent_Render_NotARepo(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	result := c.Render(ctx)
@@ -20,7 +20,7 @@ func TestComponent_Render_NotARepo(t *testing.T) {
 }
 
 func TestComponent_Render_NoUpstream(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -36,7 +36,7 @@ func TestComponent_Render_NoUpstream(t *testing.T) {
 }
 
 func TestComponent_Render_InSync(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -54,7 +54,7 @@ func TestComponent_Render_InSync(t *testing.T) {
 }
 
 func TestComponent_Render_AheadOnly(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -75,7 +75,7 @@ func TestComponent_Render_AheadOnly(t *testing.T) {
 }
 
 func TestComponent_Render_BehindOnly(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -96,7 +96,7 @@ func TestComponent_Render_BehindOnly(t *testing.T) {
 }
 
 func TestComponent_Render_AheadAndBehind(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -116,11 +116,33 @@ func TestComponent_Render_AheadAndBehind(t *testing.T) {
 	}
 }
 
+func TestComponent_Render_TimedOut(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	// Whether there is an upstream is unknown too
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:   true,
+		TimedOut: gitprovider.QueryUpstream,
+	})
+
+	if result := c.Render(ctx); !containsText(result, "?") {
+		t.Errorf("expected result to contain '?', got %q", result)
+	}
+
+	cfg := defaultConfig()
+	cfg.Unknown = ""
+	c = newComponent(cfg, "")
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string with unknown disabled, got %q", result)
+	}
+}
+
 func TestComponent_Render_CustomIcons(t *testing.T) {
 	cfg := defaultConfig()
 	cfg.AheadIcon = "A"
 	cfg.BehindIcon = "B"
-	c := &Component{config: cfg}
+	c := newComponent(cfg, "")
 	ctx := core.NewRenderContext()
 
 	ctx.Set(gitprovider.Key, &gitprovider.Info{
@@ -141,7 +163,7 @@ func TestComponent_Render_CustomIcons(t *testing.T) {
 }
 
 func TestComponent_RequiredProviders(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	providers := c.RequiredProviders()
 
 	if len(providers) != 1 || providers[0] != "git" {
@@ -150,7 +172,7 @@ func TestComponent_RequiredProviders(t *testing.T) {
 }
 
 func TestFormatCount(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "")
 	testColor := format.ParseColor("green")
 
 	// Test zero count returns empty
@@ -8,22 +8,28 @@ type Config struct {
 	//   {{.Behind}} - Commits behind upstream (e.g., "↓3")
 	Template string `yaml:"template"`
 
-	// Icons for ahead/behind.
+	// Icons for ahead/behind (glyphs or ":name:" icon references).
 	AheadIcon  string `yaml:"ahead_icon,omitempty"`
 	BehindIcon string `yaml:"behind_icon,omitempty"`
 
 	// Colors for ahead/behind.
 	AheadColor  string `yaml:"ahead_color,omitempty"`
 	BehindColor string `yaml:"behind_color,omitempty"`
+
+	// Shown when reading ahead/behind timed out (empty hides the component)
+	Unknown      string `yaml:"unknown,omitempty"`
+	UnknownColor string `yaml:"unknown_color,omitempty"`
 }
 
 // defaultConfig returns the default configuration.
 func defaultConfig() *Config {
 	return &Config{
-		Template:    "{{if .Ahead}} {{.Ahead}}{{end}}{{if .Behind}} {{.Behind}}{{end}}",
-		AheadIcon:   "\ueaa1 ",
-		BehindIcon:  "\uea9a ",
-		AheadColor:  "green",
-		BehindColor: "red",
+		Template:     "{{if .Ahead}} {{.Ahead}}{{end}}{{if .Behind}} {{.Behind}}{{end}}",
+		AheadIcon:    ":git_ahead: ",
+		BehindIcon:   ":git_behind: ",
+		AheadColor:   "green",
+		BehindColor:  "red",
+		Unknown:      "?",
+		UnknownColor: "gray",
 	}
 }
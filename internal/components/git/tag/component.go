@@ -0,0 +1,84 @@
+package tag
+
+import (
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func init() {
+	core.RegisterComponent("git.tag", New)
+}
+
+// Component displays the nearest tag and the commits since it.
+type Component struct {
+	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template   *format.Template
+	color      format.Color
+	onTagColor format.Color
+	icon       string
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Icon     string
+	Tag      string
+	Distance int
+	OnTag    bool
+}
+
+// New is the factory function for git.tag component.
+func New(cfgReader *config.Reader) core.Component {
+	return newComponent(
+		config.GetComponent(cfgReader, "git.tag", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	c.compiled = compiled{
+		template:   format.CompileTemplate(c.config.Template),
+		color:      format.ParseColor(c.config.Color),
+		onTagColor: format.ParseColor(c.config.OnTagColor),
+		icon:       c.icons.ResolveIcons(c.config.Icon),
+	}
+	return c
+}
+
+// Render generates the tag display string.
+func (c *Component) Render(ctx *core.RenderContext) string {
+	info, ok := gitprovider.GetInfo(ctx)
+	if !ok || !info.IsRepo || info.Tag == "" {
+		return ""
+	}
+
+	pre := &c.compiled
+
+	// Render template and apply color
+	result := pre.template.Render(templateData{
+		Icon:     pre.icon,
+		Tag:      info.Tag,
+		Distance: info.TagDistance,
+		OnTag:    info.OnTag,
+	})
+
+	if info.OnTag {
+		return format.Colorize(pre.onTagColor, result)
+	}
+	return format.Colorize(pre.color, result)
+}
+
+// RequiredProviders returns the list of provider names this component needs.
+func (c *Component) RequiredProviders() []string {
+	return []string{"git"}
+}
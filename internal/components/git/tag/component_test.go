@@ -0,0 +1,66 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func TestComponent_Render_NotARepo(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string for non-repo, got %q", result)
+	}
+}
+
+func TestComponent_Render_NoTag(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, Branch: "main"})
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string without a tag, got %q", result)
+	}
+}
+
+func TestComponent_Render_OnTag(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Icon = "T"
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, Tag: "v1.2.0", OnTag: true})
+
+	result := c.Render(ctx)
+
+	if want := "T v1.2.0"; !strings.Contains(result, want) || strings.Contains(result, "+") {
+		t.Errorf("expected result to contain %q without a distance, got %q", want, result)
+	}
+	if !strings.HasPrefix(result, string(format.ColorGreen)) {
+		t.Errorf("expected green when on the tag, got %q", result)
+	}
+}
+
+func TestComponent_Render_CommitsSinceTag(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Icon = "T"
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{IsRepo: true, Tag: "v1.2.0", TagDistance: 3})
+
+	result := c.Render(ctx)
+
+	if want := "T v1.2.0 +3"; !strings.Contains(result, want) {
+		t.Errorf("expected result to contain %q, got %q", want, result)
+	}
+	if !strings.HasPrefix(result, string(format.ColorCyan)) {
+		t.Errorf("expected cyan after the tag, got %q", result)
+	}
+}
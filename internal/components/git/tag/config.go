@@ -0,0 +1,32 @@
+package tag
+
+// Config defines configuration for the git.tag component.
+// The git provider only reads tags with providers.git.tags enabled.
+type Config struct {
+	// Template for display.
+	// Available variables:
+	//   {{.Icon}}     - The configured icon
+	//   {{.Tag}}      - Nearest tag reachable from HEAD
+	//   {{.Distance}} - Commits since the tag (0 on the tag)
+	//   {{.OnTag}}    - Whether HEAD is exactly on the tag
+	Template string `yaml:"template"`
+
+	// Icon to display (glyph or ":name:" icon reference).
+	Icon string `yaml:"icon,omitempty"`
+
+	// Color for the display.
+	Color string `yaml:"color,omitempty"`
+
+	// OnTagColor when HEAD is exactly on the tag.
+	OnTagColor string `yaml:"on_tag_color,omitempty"`
+}
+
+// defaultConfig returns the default configuration.
+func defaultConfig() *Config {
+	return &Config{
+		Template:   "{{.Icon}} {{.Tag}}{{if .Distance}} +{{.Distance}}{{end}}",
+		Icon:       ":git_tag:",
+		Color:      "cyan",
+		OnTagColor: "green",
+	}
+}
@@ -0,0 +1,83 @@
+package worktree
+
+import (
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func init() {
+	core.RegisterComponent("git.worktree", New)
+}
+
+// Component displays the git worktree the session works in.
+type Component struct {
+	config *Config
+	icons  format.IconSet
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template *format.Template
+	color    format.Color
+	icon     string
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Icon   string
+	Name   string
+	Path   string
+	IsMain bool
+}
+
+// New is the factory function for git.worktree component.
+func New(cfgReader *config.Reader) core.Component {
+	return newComponent(
+		config.GetComponent(cfgReader, "git.worktree", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors and icons once.
+func newComponent(cfg *Config, icons format.IconSet) *Component {
+	c := &Component{config: cfg, icons: icons}
+	c.compiled = compiled{
+		template: format.CompileTemplate(c.config.Template),
+		color:    format.ParseColor(c.config.Color),
+		icon:     c.icons.ResolveIcons(c.config.Icon),
+	}
+	return c
+}
+
+// Render generates the worktree display string.
+func (c *Component) Render(ctx *core.RenderContext) string {
+	info, ok := gitprovider.GetInfo(ctx)
+	if !ok || !info.IsRepo || info.Worktree == "" {
+		return ""
+	}
+
+	// The main worktree is the usual case, so it's hidden unless configured
+	if info.IsMainWorktree && !c.config.ShowMain {
+		return ""
+	}
+
+	pre := &c.compiled
+
+	// Render template and apply color
+	result := pre.template.Render(templateData{
+		Icon:   pre.icon,
+		Name:   info.Worktree,
+		Path:   info.WorktreePath,
+		IsMain: info.IsMainWorktree,
+	})
+	return format.Colorize(pre.color, result)
+}
+
+// RequiredProviders returns the list of provider names this component needs.
+func (c *Component) RequiredProviders() []string {
+	return []string{"git"}
+}
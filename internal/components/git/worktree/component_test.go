@@ -0,0 +1,60 @@
+package worktree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mirage20/ccstatus-go/internal/core"
+	gitprovider "github.com/mirage20/ccstatus-go/internal/providers/git"
+)
+
+func TestComponent_Render_NotARepo(t *testing.T) {
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected empty string for non-repo, got %q", result)
+	}
+}
+
+func TestComponent_Render_LinkedWorktree(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Icon = "W"
+	c := newComponent(cfg, "")
+	ctx := core.NewRenderContext()
+
+	ctx.Set(gitprovider.Key, &gitprovider.Info{
+		IsRepo:       true,
+		Worktree:     "parallel",
+		WorktreePath: "/work/parallel",
+	})
+
+	if result := c.Render(ctx); !strings.Contains(result, "W parallel") {
+		t.Errorf("expected result to contain 'W parallel', got %q", result)
+	}
+}
+
+func TestComponent_Render_MainWorktree(t *testing.T) {
+	info := &gitprovider.Info{
+		IsRepo:         true,
+		Worktree:       "project",
+		WorktreePath:   "/work/project",
+		IsMainWorktree: true,
+	}
+
+	c := newComponent(defaultConfig(), "")
+	ctx := core.NewRenderContext()
+	ctx.Set(gitprovider.Key, info)
+
+	if result := c.Render(ctx); result != "" {
+		t.Errorf("expected main worktree to be hidden by default, got %q", result)
+	}
+
+	cfg := defaultConfig()
+	cfg.ShowMain = true
+	c = newComponent(cfg, "")
+
+	if result := c.Render(ctx); !strings.Contains(result, "project") {
+		t.Errorf("expected result to contain 'project' with show_main, got %q", result)
+	}
+}
@@ -0,0 +1,30 @@
+package worktree
+
+// Config defines configuration for the git.worktree component.
+type Config struct {
+	// Template for display.
+	// Available variables:
+	//   {{.Icon}}   - The configured icon
+	//   {{.Name}}   - Worktree name (directory name for the main worktree)
+	//   {{.Path}}   - Root directory of the working tree
+	//   {{.IsMain}} - Whether this is the main worktree
+	Template string `yaml:"template"`
+
+	// Icon to display (glyph or ":name:" icon reference).
+	Icon string `yaml:"icon,omitempty"`
+
+	// Color for the display.
+	Color string `yaml:"color,omitempty"`
+
+	// ShowMain also shows the main worktree; by default only linked worktrees are shown.
+	ShowMain bool `yaml:"show_main,omitempty"`
+}
+
+// defaultConfig returns the default configuration.
+func defaultConfig() *Config {
+	return &Config{
+		Template: "{{.Icon}} {{.Name}}",
+		Icon:     ":git_worktree:",
+		Color:    "blue",
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/mirage20/ccstatus-go/internal/core"
+	"github.com/mirage20/ccstatus-go/internal/format"
 	"github.com/mirage20/ccstatus-go/internal/providers/sessioninfo"
 )
 
@@ -167,11 +168,26 @@ func TestRender(t *testing.T) {
 			// Remaining time should be cyan instead of gray
 			wantPrefix: "\033[32m5h\033[0m \033[32m25%\033[0m \033[36m",
 		},
+		{
+			name: "renders utilization bar in status color",
+			config: func() *Config {
+				cfg := defaultConfig()
+				cfg.Template = "{{.Icon}} {{.Bar}}"
+				cfg.BarWidth = 4
+				return cfg
+			}(),
+			rateLimits: &core.SessionRateLimits{
+				FiveHour: &core.SessionRateLimit{
+					UsedPercentage: 65.0, // 2.6 cells
+				},
+			},
+			want: "\033[33m5h\033[0m \033[33m██▋░\033[0m",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := &Component{config: tt.config}
+			c := newComponent(tt.config, "", format.Locale{})
 			ctx := core.NewRenderContext()
 
 			info := &sessioninfo.SessionInfo{
@@ -194,10 +210,29 @@ func TestRender(t *testing.T) {
 
 // TestRequiredProviders tests that the component declares its dependencies.
 func TestRequiredProviders(t *testing.T) {
-	c := &Component{config: defaultConfig()}
+	c := newComponent(defaultConfig(), "", format.Locale{})
 	providers := c.RequiredProviders()
 
 	if len(providers) != 1 || providers[0] != "sessioninfo" {
 		t.Errorf("RequiredProviders() = %v, want [sessioninfo]", providers)
 	}
 }
+
+// TestRenderLocale tests that percentages, end times and labels follow the locale.
+func TestRenderLocale(t *testing.T) {
+	resetsAt := time.Date(2025, time.January, 6, 14, 30, 0, 0, time.Local).Unix() // A Monday
+	cfg := defaultConfig()
+	cfg.Template = "{{.Utilization}} {{.EndTime}} {{.Labels.remaining}}"
+	c := newComponent(cfg, "", format.ParseLocale("de_DE"))
+	ctx := core.NewRenderContext()
+	ctx.Set(sessioninfo.Key, &sessioninfo.SessionInfo{
+		RateLimits: &core.SessionRateLimits{
+			FiveHour: &core.SessionRateLimit{UsedPercentage: 25.0, ResetsAt: &resetsAt},
+		},
+	})
+
+	want := "\033[32m25 %\033[0m \033[90m14:30\033[0m übrig"
+	if got := c.Render(ctx); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
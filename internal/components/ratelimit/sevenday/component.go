@@ -1,12 +1,13 @@
 package sevenday
 
 import (
-	"fmt"
+	"strings"
 	"time"
 
 	"github.com/mirage20/ccstatus-go/internal/config"
 	"github.com/mirage20/ccstatus-go/internal/core"
 	"github.com/mirage20/ccstatus-go/internal/format"
+	"github.com/mirage20/ccstatus-go/internal/providers/history"
 	"github.com/mirage20/ccstatus-go/internal/providers/sessioninfo"
 )
 
@@ -17,14 +18,57 @@ func init() {
 // Component displays the 7-day rate limit usage.
 type Component struct {
 	config *Config
+	icons  format.IconSet
+	locale format.Locale
+
+	compiled compiled
+}
+
+// compiled holds values precomputed from the config.
+type compiled struct {
+	template      *format.Template
+	icon          string
+	labels        map[string]string
+	infoColor     format.Color
+	criticalColor format.Color
+	warningColor  format.Color
+	normalColor   format.Color
+}
+
+// templateData is the data available to the template.
+type templateData struct {
+	Icon        string
+	Utilization string
+	Bar         string
+	Sparkline   string
+	Remaining   string
+	EndTime     string
+	EndTimeRaw  *time.Time
+	Labels      map[string]string
 }
 
 // New is the factory function for the 7-day rate limit component.
 func New(cfgReader *config.Reader) core.Component {
-	cfg := config.GetComponent(cfgReader, "ratelimit.sevenday", defaultConfig())
-	return &Component{
-		config: cfg,
+	return newComponent(
+		config.GetComponent(cfgReader, "ratelimit.sevenday", defaultConfig()),
+		format.ParseIconSet(config.Get(cfgReader, "icons", "")),
+		config.GetLocale(cfgReader),
+	)
+}
+
+// newComponent creates the component, parsing its template, colors, icons and labels once.
+func newComponent(cfg *Config, icons format.IconSet, locale format.Locale) *Component {
+	c := &Component{config: cfg, icons: icons, locale: locale}
+	c.compiled = compiled{
+		template:      format.CompileTemplate(c.config.Template),
+		icon:          c.icons.ResolveIcons(c.config.Icon),
+		labels:        c.locale.AllLabels(),
+		infoColor:     format.ParseColor(c.config.Color),
+		criticalColor: format.ParseColor(c.config.CriticalColor),
+		warningColor:  format.ParseColor(c.config.WarningColor),
+		normalColor:   format.ParseColor(c.config.NormalColor),
 	}
+	return c
 }
 
 // Render generates the rate limit display string.
@@ -34,18 +78,16 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 		return ""
 	}
 
-	infoColor := format.ParseColor(c.config.Color)
+	pre := &c.compiled
+	infoColor := pre.infoColor
 
 	// When rate limit data is not available, show placeholder
 	if info.RateLimits == nil || info.RateLimits.SevenDay == nil {
-		data := map[string]interface{}{
-			"Icon":        format.Colorize(infoColor, c.config.Icon),
-			"Utilization": format.Colorize(infoColor, "--"),
-			"Remaining":   "",
-			"EndTime":     "",
-			"EndTimeRaw":  (*time.Time)(nil),
-		}
-		return format.RenderTemplate(c.config.Template, data)
+		return pre.template.Render(templateData{
+			Icon:        format.Colorize(infoColor, pre.icon),
+			Utilization: format.Colorize(infoColor, "--"),
+			Labels:      pre.labels,
+		})
 	}
 
 	sevenDay := info.RateLimits.SevenDay
@@ -61,74 +103,79 @@ func (c *Component) Render(ctx *core.RenderContext) string {
 	remaining := ""
 	endTime := ""
 	if resetsAt != nil {
-		remaining = formatRemainingDays(*resetsAt)
-		endTime = resetsAt.Local().Format(c.config.EndTimeFormat)
+		remaining = c.formatRemainingDays(*resetsAt)
+		endTime = c.locale.Time(*resetsAt, c.endTimeFormat())
 	}
 
 	// Determine colors
-	statusColor := c.getUsageColor(sevenDay.UsedPercentage)
+	statusColor := c.getUsageColor(pre, sevenDay.UsedPercentage)
 
 	// Build template data with pre-colored values
 	// Icon and Utilization use status color (green/yellow/red)
 	// Remaining and EndTime use info color (gray) as supplementary info
-	data := map[string]interface{}{
-		"Icon":        format.Colorize(statusColor, c.config.Icon),
-		"Utilization": format.Colorize(statusColor, fmt.Sprintf("%.0f%%", sevenDay.UsedPercentage)),
-		"Remaining":   format.Colorize(infoColor, remaining),
-		"EndTime":     format.Colorize(infoColor, endTime),
-		"EndTimeRaw":  resetsAt,
-	}
-
 	// Render template (values are pre-colored)
-	return format.RenderTemplate(c.config.Template, data)
+	return pre.template.Render(templateData{
+		Icon:        format.Colorize(statusColor, pre.icon),
+		Utilization: format.Colorize(statusColor, c.locale.Percent(sevenDay.UsedPercentage)),
+		Bar:         format.Colorize(statusColor, c.renderBar(sevenDay.UsedPercentage)),
+		Sparkline:   format.Colorize(statusColor, c.renderSparkline(ctx)),
+		Remaining:   format.Colorize(infoColor, remaining),
+		EndTime:     format.Colorize(infoColor, endTime),
+		EndTimeRaw:  resetsAt,
+		Labels:      pre.labels,
+	})
 }
 
 // RequiredProviders returns the list of provider names this component needs.
+// History is only gathered when the template uses it.
 func (c *Component) RequiredProviders() []string {
+	if strings.Contains(c.config.Template, ".Sparkline") {
+		return []string{"sessioninfo", string(history.Key)}
+	}
 	return []string{"sessioninfo"}
 }
 
+// renderBar renders the utilization bar, using ASCII cells for the ascii icon set.
+func (c *Component) renderBar(utilization float64) string {
+	return format.Bar(utilization, c.config.BarWidth, c.icons == format.IconSetASCII)
+}
+
+// renderSparkline renders the utilization trend from the history provider, if available.
+func (c *Component) renderSparkline(ctx *core.RenderContext) string {
+	h, ok := history.GetHistory(ctx)
+	if !ok {
+		return ""
+	}
+	return format.Sparkline(h.SevenDayUtilization(), 100, c.icons == format.IconSetASCII)
+}
+
 // getUsageColor returns color based on utilization and configured thresholds.
-func (c *Component) getUsageColor(utilization float64) format.Color {
+func (c *Component) getUsageColor(pre *compiled, utilization float64) format.Color {
 	switch {
 	case utilization >= c.config.CriticalThreshold:
-		return format.ParseColor(c.config.CriticalColor)
+		return pre.criticalColor
 	case utilization >= c.config.WarningThreshold:
-		return format.ParseColor(c.config.WarningColor)
+		return pre.warningColor
 	default:
-		return format.ParseColor(c.config.NormalColor)
+		return pre.normalColor
+	}
+}
+
+// endTimeFormat returns the configured end time layout or the locale's default.
+func (c *Component) endTimeFormat() string {
+	if c.config.EndTimeFormat != "" {
+		return c.config.EndTimeFormat
 	}
+	return c.locale.WeekdayTimeLayout()
 }
 
 // formatRemainingDays formats the time until reset with days support.
 // Examples: "2d3h", "5h30m", "45m", "0m".
-func formatRemainingDays(resetTime time.Time) string {
-	const (
-		minutesPerHour = 60
-		hoursPerDay    = 24
-		minutesPerDay  = hoursPerDay * minutesPerHour
-	)
-
+func (c *Component) formatRemainingDays(resetTime time.Time) string {
 	remaining := time.Until(resetTime)
 	if remaining <= 0 {
-		return "0m"
+		return c.locale.DurationMinutes(0)
 	}
 
-	totalMinutes := int(remaining.Minutes())
-	days := totalMinutes / minutesPerDay
-	hours := (totalMinutes % minutesPerDay) / minutesPerHour
-	minutes := totalMinutes % minutesPerHour
-
-	switch {
-	case days > 0 && hours > 0:
-		return fmt.Sprintf("%dd%dh", days, hours)
-	case days > 0:
-		return fmt.Sprintf("%dd", days)
-	case hours > 0 && minutes > 0:
-		return fmt.Sprintf("%dh%dm", hours, minutes)
-	case hours > 0:
-		return fmt.Sprintf("%dh", hours)
-	default:
-		return fmt.Sprintf("%dm", minutes)
-	}
+	return c.locale.DurationDays(int(remaining.Minutes()))
 }
@@ -4,6 +4,8 @@ const (
 	// Default thresholds for usage levels (in percentage).
 	defaultWarningThreshold  = 60.0
 	defaultCriticalThreshold = 80.0
+	// Default width of the utilization bar in cells.
+	defaultBarWidth = 10
 )
 
 // Config defines configuration for the 7-day rate limit component.
@@ -15,15 +17,21 @@ type Config struct {
 	//   {{.Remaining}}    - Formatted remaining time (e.g. "2d3h")
 	//   {{.EndTime}}      - Formatted reset time (e.g. "Sat 4:29 PM")
 	//   {{.EndTimeRaw}}   - Raw reset time for custom formatting
+	//   {{.Bar}}          - Utilization bar (e.g. "██▍░░░░░░░")
+	//   {{.Sparkline}}    - Utilization trend across turns (e.g. "▁▂▃▅▇")
+	//   {{.Labels}}       - Translated labels by key (e.g. {{.Labels.remaining}})
 	Template string `yaml:"template"`
 
 	// Icon/label to display (default: "7d")
 	Icon string `yaml:"icon,omitempty"`
 
-	// Time format for end time (Go time format)
+	// Time format for end time (Go time format, empty = locale default)
 	// Examples: "Mon 3:04 PM", "Jan 2 15:04"
 	EndTimeFormat string `yaml:"end_time_format,omitempty"`
 
+	// Width of the utilization bar in cells (0 = no bar)
+	BarWidth int `yaml:"bar_width,omitempty"`
+
 	// Color thresholds (percentages)
 	WarningThreshold  float64 `yaml:"warning_threshold,omitempty"`
 	CriticalThreshold float64 `yaml:"critical_threshold,omitempty"`
@@ -42,7 +50,8 @@ func defaultConfig() *Config {
 	return &Config{
 		Template:          "{{.Icon}} {{.Utilization}}{{if .EndTime}} {{.Remaining}}{{end}}",
 		Icon:              "7d",
-		EndTimeFormat:     "Mon 3:04 PM",
+		EndTimeFormat:     "", // Locale default ("Mon 3:04 PM" or "Mon 15:04")
+		BarWidth:          defaultBarWidth,
 		WarningThreshold:  defaultWarningThreshold,
 		CriticalThreshold: defaultCriticalThreshold,
 		NormalColor:       "green",
@@ -3,29 +3,59 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"time"
 
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/v2"
+
+	"github.com/mirage20/ccstatus-go/internal/format"
 )
 
 // Reader provides access to configuration values.
 type Reader struct {
-	k *koanf.Koanf
+	k          *koanf.Koanf
+	projectDir string
+	path       string    // Loaded config file, empty if none
+	modTime    time.Time // Modification time of the loaded config file
+	active     []string  // Active component names, read once as providers check them every render
 }
 
 // NewReader creates a new configuration reader.
 func NewReader(projectDir string) *Reader {
 	k := koanf.New(".")
+	r := &Reader{k: k, projectDir: projectDir}
 
 	// Load user config file if it exists
 	configPath := findConfigFile(projectDir)
 	if configPath != "" {
+		r.path = configPath
+		if info, err := os.Stat(configPath); err == nil {
+			r.modTime = info.ModTime()
+		}
+
 		// Try to load config, but continue with defaults if it fails
 		_ = k.Load(file.Provider(configPath), yaml.Parser())
 	}
+	r.active = k.Strings("active")
 
-	return &Reader{k: k}
+	return r
+}
+
+// Changed reports whether NewReader would now load a different config file,
+// or the loaded file was modified. Long-running processes use it to reload config.
+func (r *Reader) Changed() bool {
+	configPath := findConfigFile(r.projectDir)
+	if configPath != r.path {
+		return true
+	}
+	if configPath == "" {
+		return false
+	}
+
+	info, err := os.Stat(configPath)
+	return err != nil || !info.ModTime().Equal(r.modTime)
 }
 
 // findConfigFile searches for config file in order of preference.
@@ -76,6 +106,12 @@ func Get[T any](r *Reader, path string, defaultValue T) T {
 	return result
 }
 
+// IsActive reports whether a component is named in the "active" list.
+// Components only in the built-in default list aren't reported.
+func IsActive(r *Reader, component string) bool {
+	return slices.Contains(r.active, component)
+}
+
 // GetComponent retrieves configuration for a specific component.
 func GetComponent[T any](r *Reader, componentName string, defaultValue T) T {
 	path := "components." + componentName
@@ -87,3 +123,8 @@ func GetProvider[T any](r *Reader, providerName string, defaultValue T) T {
 	path := "providers." + providerName
 	return Get(r, path, defaultValue)
 }
+
+// GetLocale returns the locale named by "locale", with fields from "locale_overrides" replacing its own.
+func GetLocale(r *Reader) format.Locale {
+	return Get(r, "locale_overrides", format.ParseLocale(Get(r, "locale", "")))
+}
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/knadh/koanf/v2"
 )
@@ -265,3 +268,88 @@ func TestGetComponent(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLocale(t *testing.T) {
+	k := koanf.New(".")
+	_ = k.Set("locale", "de_AT")
+	_ = k.Set("locale_overrides.decimal_separator", ".")
+	_ = k.Set("locale_overrides.labels.resets", "neu")
+
+	loc := GetLocale(&Reader{k: k})
+
+	// Overridden fields replace the locale's, the rest are kept
+	if loc.DecimalSeparator != "." || loc.PercentSign != " %" {
+		t.Errorf("GetLocale() separator %q, percent sign %q, want \".\" and \" %%\"", loc.DecimalSeparator, loc.PercentSign)
+	}
+	if got := loc.Label("resets"); got != "neu" {
+		t.Errorf("Label(resets) = %q, want neu", got)
+	}
+	if got := loc.Label("remaining"); got != "übrig" {
+		t.Errorf("Label(remaining) = %q, want übrig", got)
+	}
+
+	if loc = GetLocale(&Reader{}); loc.Clock != "" || loc.DecimalSeparator != "" {
+		t.Errorf("GetLocale() without config = %+v, want English", loc)
+	}
+}
+
+func TestIsActive(t *testing.T) {
+	projectDir := t.TempDir()
+	claudeDir := filepath.Join(projectDir, ".claude")
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		t.Fatalf("failed to create .claude: %v", err)
+	}
+	content := []byte("active: [git.branch, git.remote]\n")
+	if err := os.WriteFile(filepath.Join(claudeDir, "ccstatus.yaml"), content, 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	r := NewReader(projectDir)
+
+	if !IsActive(r, "git.remote") {
+		t.Error("IsActive(git.remote) = false, want true")
+	}
+	if IsActive(r, "git.diff") {
+		t.Error("IsActive(git.diff) = true, want false")
+	}
+	if IsActive(&Reader{}, "git.remote") {
+		t.Error("IsActive() = true without config")
+	}
+}
+
+func TestReaderChanged(t *testing.T) {
+	projectDir := t.TempDir()
+	claudeDir := filepath.Join(projectDir, ".claude")
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		t.Fatalf("failed to create .claude: %v", err)
+	}
+	shared := filepath.Join(claudeDir, "ccstatus.yaml")
+	if err := os.WriteFile(shared, []byte("icons: ascii\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	r := NewReader(projectDir)
+	if got := Get(r, "icons", ""); got != "ascii" {
+		t.Fatalf("Get(icons) = %q, want ascii", got)
+	}
+	if r.Changed() {
+		t.Error("Changed() = true right after loading")
+	}
+
+	// Modifying the loaded file
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(shared, later, later); err != nil {
+		t.Fatalf("Chtimes() error: %v", err)
+	}
+	if !r.Changed() {
+		t.Error("Changed() = false after config file was modified")
+	}
+
+	// A higher priority file appearing
+	r = NewReader(projectDir)
+	if err := os.WriteFile(filepath.Join(claudeDir, "ccstatus.local.yaml"), []byte("icons: emoji\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if !r.Changed() {
+		t.Error("Changed() = false after local config appeared")
+	}
+}
@@ -18,3 +18,26 @@ type Cache interface {
 	// Should be called when done using the cache
 	Close() error
 }
+
+// CacheScope determines which invocations share cached provider data.
+type CacheScope string
+
+// Supported cache scopes.
+const (
+	// CacheScopeSession isolates entries per Claude session (default).
+	CacheScopeSession CacheScope = "session"
+	// CacheScopeWorkspace shares entries between sessions in the same workspace.
+	CacheScopeWorkspace CacheScope = "workspace"
+	// CacheScopeGlobal shares entries between all sessions.
+	CacheScopeGlobal CacheScope = "global"
+)
+
+// CacheStore hands out caches for each scope.
+type CacheStore interface {
+	// ForScope returns the cache for a scope.
+	// workspace identifies the workspace for CacheScopeWorkspace and is ignored otherwise.
+	ForScope(scope CacheScope, workspace string) Cache
+
+	// Close closes every cache handed out by the store
+	Close() error
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 )
 
@@ -11,6 +12,16 @@ type CachingProvider struct {
 	cache       Cache
 	ttl         time.Duration
 	newInstance func() interface{} // Creates new instance for unmarshaling
+	prefetch    time.Duration      // Hits this close to expiry queue a background refresh
+	scope       CacheScope
+	workspace   string
+}
+
+// cachedEntry is the cached form of provider data.
+type cachedEntry struct {
+	Fingerprint string          `json:"fingerprint,omitempty"` // Set for FingerprintProvider data
+	CachedAt    time.Time       `json:"cached_at"`
+	Data        json.RawMessage `json:"data"`
 }
 
 // NewCachingProvider creates a new caching wrapper for a provider.
@@ -28,30 +39,87 @@ func (cp *CachingProvider) Key() ProviderKey {
 	return cp.provider.Key()
 }
 
+// Scope returns the cache scope and workspace key the provider caches under.
+func (cp *CachingProvider) Scope() (CacheScope, string) {
+	return cp.scope, cp.workspace
+}
+
 // Provide fetches data from cache or underlying provider.
+// For a FingerprintProvider, entries are only used while the fingerprint is unchanged.
+// A hit within the prefetch window of expiry is added to the context's PrefetchQueue.
 func (cp *CachingProvider) Provide(ctx context.Context) (interface{}, error) {
 	cacheKey := string(cp.provider.Key())
+	fingerprint := cp.fingerprint()
 
 	// Try cache first
 	if cp.cache != nil && cp.newInstance != nil {
 		instance := cp.newInstance()
-		found, err := cp.cache.Get(cacheKey, instance)
-		if found && err == nil {
+		if cachedAt, ok := cp.get(cacheKey, instance, fingerprint); ok {
+			if cp.prefetch > 0 && time.Until(cachedAt.Add(cp.ttl)) <= cp.prefetch {
+				if queue := PrefetchQueueFrom(ctx); queue != nil {
+					queue.Add(cacheKey)
+				}
+			}
 			return instance, nil
 		}
-		// If cache miss or error, fetch fresh data
+		// If cache miss, stale fingerprint or error, fetch fresh data
 	}
 
-	// Fetch from underlying provider
+	return cp.fetch(ctx, cacheKey, fingerprint)
+}
+
+// Refresh fetches fresh data from the underlying provider and caches it, ignoring any cached entry.
+func (cp *CachingProvider) Refresh(ctx context.Context) (interface{}, error) {
+	return cp.fetch(ctx, string(cp.provider.Key()), cp.fingerprint())
+}
+
+// fetch gets data from the underlying provider and caches it.
+func (cp *CachingProvider) fetch(ctx context.Context, cacheKey, fingerprint string) (interface{}, error) {
 	data, err := cp.provider.Provide(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	// Partial results aren't cached, so the next invocation tries again
+	if partial, ok := data.(PartialData); ok && partial.Partial() {
+		return data, nil
+	}
+
 	// Cache the result (ignore cache errors - they shouldn't break the flow)
 	if cp.cache != nil && cp.ttl > 0 {
-		_ = cp.cache.Set(cacheKey, data, cp.ttl)
+		cp.set(cacheKey, data, fingerprint)
 	}
 
 	return data, nil
 }
+
+// fingerprint returns the provider's current fingerprint, or empty string if it has none.
+func (cp *CachingProvider) fingerprint() string {
+	if fingerprinter, ok := cp.provider.(FingerprintProvider); ok {
+		return fingerprinter.Fingerprint()
+	}
+	return ""
+}
+
+// get reads a cached entry into instance if its fingerprint matches.
+// Returns when the entry was cached.
+func (cp *CachingProvider) get(cacheKey string, instance any, fingerprint string) (time.Time, bool) {
+	var e cachedEntry
+	found, err := cp.cache.Get(cacheKey, &e)
+	if !found || err != nil || e.Fingerprint != fingerprint || e.Data == nil {
+		return time.Time{}, false
+	}
+	if json.Unmarshal(e.Data, instance) != nil {
+		return time.Time{}, false
+	}
+	return e.CachedAt, true
+}
+
+// set stores data with its fingerprint and caching time.
+func (cp *CachingProvider) set(cacheKey string, data any, fingerprint string) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	_ = cp.cache.Set(cacheKey, cachedEntry{Fingerprint: fingerprint, CachedAt: time.Now(), Data: raw}, cp.ttl)
+}
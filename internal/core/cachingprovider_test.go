@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// countingProvider returns an incrementing counter on every fetch.
+type countingProvider struct {
+	calls int
+}
+
+func (p *countingProvider) Key() ProviderKey {
+	return "counter"
+}
+
+func (p *countingProvider) Provide(context.Context) (any, error) {
+	p.calls++
+	value := p.calls
+	return &value, nil
+}
+
+func newCountingCache(p *countingProvider, ttl, prefetch time.Duration) *CachingProvider {
+	cp := NewCachingProvider(p, mapCache{}, ttl, func() any { return new(int) })
+	cp.prefetch = prefetch
+	return cp
+}
+
+func TestCachingProvider_Prefetch(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		prefetch  time.Duration
+		wantQueue []string
+	}{
+		{name: "far from expiry", ttl: time.Hour, prefetch: time.Second},
+		{name: "within prefetch window", ttl: time.Second, prefetch: time.Hour, wantQueue: []string{"counter"}},
+		{name: "prefetch disabled", ttl: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &countingProvider{}
+			cp := newCountingCache(p, tt.ttl, tt.prefetch)
+			queue := &PrefetchQueue{}
+			ctx := WithPrefetchQueue(context.Background(), queue)
+
+			// Miss then hit; only hits are queued
+			_, _ = cp.Provide(ctx)
+			if len(queue.Names()) != 0 {
+				t.Fatalf("miss queued a prefetch: %v", queue.Names())
+			}
+			result, _ := cp.Provide(ctx)
+			if got := *result.(*int); got != 1 || p.calls != 1 {
+				t.Fatalf("second Provide() = %d after %d calls, want cached 1", got, p.calls)
+			}
+			if got := queue.Names(); !slices.Equal(got, tt.wantQueue) {
+				t.Errorf("queue = %v, want %v", got, tt.wantQueue)
+			}
+		})
+	}
+}
+
+// partialProvider returns data that is partial on the first fetch only.
+type partialProvider struct {
+	calls int
+}
+
+type partialData struct {
+	Call    int  `json:"call"`
+	Missing bool `json:"missing"`
+}
+
+func (d *partialData) Partial() bool { return d.Missing }
+
+func (p *partialProvider) Key() ProviderKey {
+	return "partial"
+}
+
+func (p *partialProvider) Provide(context.Context) (any, error) {
+	p.calls++
+	return &partialData{Call: p.calls, Missing: p.calls == 1}, nil
+}
+
+func TestCachingProvider_PartialNotCached(t *testing.T) {
+	p := &partialProvider{}
+	cp := NewCachingProvider(p, mapCache{}, time.Hour, func() any { return &partialData{} })
+
+	// The partial result is returned but not cached; the complete one is
+	for _, want := range []int{1, 2, 2} {
+		result, _ := cp.Provide(context.Background())
+		if got := result.(*partialData).Call; got != want {
+			t.Errorf("Provide() = call %d, want %d", got, want)
+		}
+	}
+}
+
+func TestCachingProvider_Refresh(t *testing.T) {
+	p := &countingProvider{}
+	cp := newCountingCache(p, time.Hour, 0)
+
+	_, _ = cp.Provide(context.Background())
+	if _, err := cp.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error: %v", err)
+	}
+
+	// The refreshed value is cached
+	result, _ := cp.Provide(context.Background())
+	if got := *result.(*int); got != 2 || p.calls != 2 {
+		t.Errorf("Provide() after Refresh = %d after %d calls, want cached 2", got, p.calls)
+	}
+}
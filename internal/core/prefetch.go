@@ -0,0 +1,46 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// prefetchQueueKey is the context key for the PrefetchQueue.
+type prefetchQueueKey struct{}
+
+// PrefetchQueue collects the names of providers whose cached data is close to expiry,
+// so they can be refreshed in the background after the status line is printed.
+type PrefetchQueue struct {
+	mu    sync.Mutex
+	names []string
+}
+
+// WithPrefetchQueue returns a context carrying queue.
+func WithPrefetchQueue(ctx context.Context, queue *PrefetchQueue) context.Context {
+	return context.WithValue(ctx, prefetchQueueKey{}, queue)
+}
+
+// PrefetchQueueFrom returns the queue carried by ctx, or nil if there is none.
+func PrefetchQueueFrom(ctx context.Context) *PrefetchQueue {
+	queue, _ := ctx.Value(prefetchQueueKey{}).(*PrefetchQueue)
+	return queue
+}
+
+// Add queues a provider by name; duplicates are ignored.
+func (q *PrefetchQueue) Add(name string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !slices.Contains(q.names, name) {
+		q.names = append(q.names, name)
+	}
+}
+
+// Names returns the queued provider names in the order they were added.
+func (q *PrefetchQueue) Names() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return slices.Clone(q.names)
+}
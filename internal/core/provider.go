@@ -20,13 +20,49 @@ type Provider interface {
 	Provide(ctx context.Context) (interface{}, error)
 }
 
+// CacheAwareProvider is a provider that manages its own cache entries,
+// e.g. to accumulate data across invocations instead of caching a single result.
+type CacheAwareProvider interface {
+	Provider
+
+	// UseCache hands the session cache to the provider before the first Provide call
+	UseCache(cache Cache)
+}
+
+// WorkspaceScopedProvider is a provider that chooses the key for its workspace-scoped cache,
+// e.g. the repository root so all sessions in a working tree share entries.
+type WorkspaceScopedProvider interface {
+	Provider
+
+	// CacheWorkspace returns the path identifying the provider's workspace
+	CacheWorkspace() string
+}
+
+// FingerprintProvider is a provider that can cheaply tell whether cached data is still valid.
+// CachingProvider stores the fingerprint with each entry and treats a changed fingerprint as a miss.
+type FingerprintProvider interface {
+	Provider
+
+	// Fingerprint returns a value that changes whenever the provided data may have changed
+	Fingerprint() string
+}
+
+// PartialData is provided data that may be incomplete, e.g. because a query timed out.
+// CachingProvider doesn't cache partial data, so the next invocation tries again.
+type PartialData interface {
+	// Partial reports whether some of the data is missing
+	Partial() bool
+}
+
 // ============================================================================
 // Provider Registry
 // ============================================================================
 
 // CacheConfig represents cache configuration for a provider.
 type CacheConfig struct {
-	TTL time.Duration `yaml:"ttl"`
+	TTL      time.Duration `yaml:"ttl"`
+	Scope    CacheScope    `yaml:"scope"`    // Empty means CacheScopeSession
+	Prefetch time.Duration `yaml:"prefetch"` // Refresh in the background when a hit is this close to expiry (0 = never)
 }
 
 // ProviderFactory is a function that creates a provider from config and session,
@@ -37,6 +73,7 @@ type ProviderFactory func(cfgReader *config.Reader, session *ClaudeSession) (Pro
 type ProviderRegistration struct {
 	Factory     ProviderFactory
 	NewInstance func() interface{} // Creates new instance for unmarshaling
+	Schema      int                // Version of the provided data type; cached entries of other versions are misses
 }
 
 // providerRegistry holds all registered provider factories.
@@ -50,18 +87,21 @@ var providerRegistryInstance = &providerRegistry{
 	registrations: make(map[string]*ProviderRegistration),
 }
 
-// RegisterProvider registers a provider factory with a name and type factory.
-func RegisterProvider(name string, factory ProviderFactory, newInstance func() interface{}) {
+// RegisterProvider registers a provider factory with a name, type factory and schema version.
+// The schema version starts at 1 and must be bumped whenever the provided data type changes
+// incompatibly, so entries cached by older builds are ignored instead of decoded wrongly.
+func RegisterProvider(name string, factory ProviderFactory, newInstance func() interface{}, schema int) {
 	providerRegistryInstance.mu.Lock()
 	defer providerRegistryInstance.mu.Unlock()
 	providerRegistryInstance.registrations[name] = &ProviderRegistration{
 		Factory:     factory,
 		NewInstance: newInstance,
+		Schema:      schema,
 	}
 }
 
 // CreateProvider creates a provider by name using the registered factory.
-func CreateProvider(name string, cfgReader *config.Reader, session *ClaudeSession, cache Cache) (Provider, bool) {
+func CreateProvider(name string, cfgReader *config.Reader, session *ClaudeSession, caches CacheStore) (Provider, bool) {
 	providerRegistryInstance.mu.RLock()
 	registration, exists := providerRegistryInstance.registrations[name]
 	providerRegistryInstance.mu.RUnlock()
@@ -76,10 +116,39 @@ func CreateProvider(name string, cfgReader *config.Reader, session *ClaudeSessio
 		return nil, false
 	}
 
+	// Pick the cache for the provider's scope
+	var cache Cache
+	workspace := workspaceKey(provider, session)
+	if caches != nil {
+		cache = NewVersionedCache(caches.ForScope(cacheConfig.Scope, workspace), registration.Schema)
+	}
+
+	// Give cache-aware providers direct access to the cache
+	if aware, ok := provider.(CacheAwareProvider); ok && cache != nil {
+		aware.UseCache(cache)
+	}
+
 	// Apply caching if TTL is configured
 	if cache != nil && cacheConfig.TTL > 0 {
-		provider = NewCachingProvider(provider, cache, cacheConfig.TTL, registration.NewInstance)
+		cachingProvider := NewCachingProvider(provider, cache, cacheConfig.TTL, registration.NewInstance)
+		cachingProvider.prefetch = cacheConfig.Prefetch
+		cachingProvider.scope = cacheConfig.Scope
+		cachingProvider.workspace = workspace
+		provider = cachingProvider
 	}
 
 	return provider, true
 }
+
+// workspaceKey returns the path identifying the provider's workspace for scoped caching.
+func workspaceKey(provider Provider, session *ClaudeSession) string {
+	if scoped, ok := provider.(WorkspaceScopedProvider); ok {
+		if key := scoped.CacheWorkspace(); key != "" {
+			return key
+		}
+	}
+	if session.Workspace.ProjectDir != "" {
+		return session.Workspace.ProjectDir
+	}
+	return session.Workspace.CurrentDir
+}
@@ -19,7 +19,7 @@ type SeparatorConfig struct {
 type StatusLine struct {
 	providers  []Provider
 	components []Component
-	separator  SeparatorConfig
+	separator  string // Colored separator symbol
 }
 
 // NewStatusLine creates a new status line with configuration.
@@ -31,7 +31,7 @@ func NewStatusLine(cfgReader *config.Reader) *StatusLine {
 	})
 
 	return &StatusLine{
-		separator: separator,
+		separator: format.Colorize(format.ParseColor(separator.Color), separator.Symbol),
 	}
 }
 
@@ -69,10 +69,6 @@ func (sl *StatusLine) Render(ctx context.Context) string {
 		}
 	}
 
-	// Build colored separator
-	separatorColor := format.ParseColor(sl.separator.Color)
-	coloredSeparator := format.Colorize(separatorColor, sl.separator.Symbol)
-
 	// Group outputs by newline for multi-line support
 	var lines [][]string
 	var currentLine []string
@@ -91,7 +87,7 @@ func (sl *StatusLine) Render(ctx context.Context) string {
 	var renderedLines []string
 	for _, line := range lines {
 		if len(line) > 0 {
-			renderedLines = append(renderedLines, strings.Join(line, coloredSeparator))
+			renderedLines = append(renderedLines, strings.Join(line, sl.separator))
 		}
 	}
 
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// VersionedCache wraps a cache so every entry records the schema version of its data.
+// Entries written with a different schema version (or before versioning) are misses.
+type VersionedCache struct {
+	cache  Cache
+	schema int
+}
+
+// versionedEntry is the stored form of a versioned value.
+type versionedEntry struct {
+	Schema int             `json:"schema"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// NewVersionedCache creates a cache that stores and checks the given schema version.
+func NewVersionedCache(cache Cache, schema int) *VersionedCache {
+	return &VersionedCache{
+		cache:  cache,
+		schema: schema,
+	}
+}
+
+// Get retrieves cached data if it was stored with the same schema version.
+func (vc *VersionedCache) Get(key string, target any) (bool, error) {
+	var e versionedEntry
+	found, err := vc.cache.Get(key, &e)
+	if !found || err != nil || e.Schema != vc.schema || e.Data == nil {
+		// Entries of another schema are stale, not errors
+		return false, nil //nolint:nilerr // undecodable entries are treated as misses
+	}
+
+	err = json.Unmarshal(e.Data, target)
+	return err == nil, err
+}
+
+// Set stores data together with the schema version.
+func (vc *VersionedCache) Set(key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return vc.cache.Set(key, versionedEntry{Schema: vc.schema, Data: data}, ttl)
+}
+
+// Delete removes cached data.
+func (vc *VersionedCache) Delete(key string) error {
+	return vc.cache.Delete(key)
+}
+
+// Close closes the underlying cache.
+func (vc *VersionedCache) Close() error {
+	return vc.cache.Close()
+}
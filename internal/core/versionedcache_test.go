@@ -0,0 +1,73 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// mapCache is an in-memory Cache for tests.
+type mapCache map[string]any
+
+func (m mapCache) Get(key string, target any) (bool, error) {
+	raw, ok := m[key].([]byte)
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, target)
+}
+
+func (m mapCache) Set(key string, value any, _ time.Duration) error {
+	raw, err := json.Marshal(value)
+	m[key] = raw
+	return err
+}
+
+func (m mapCache) Delete(key string) error {
+	delete(m, key)
+	return nil
+}
+
+func (m mapCache) Close() error {
+	return nil
+}
+
+type testInfo struct {
+	Branch string
+}
+
+func TestVersionedCache(t *testing.T) {
+	backing := mapCache{}
+	_ = NewVersionedCache(backing, 1).Set("git", testInfo{Branch: "main"}, time.Minute)
+
+	// Entry written before versioning existed
+	_ = backing.Set("legacy", testInfo{Branch: "old"}, time.Minute)
+
+	tests := []struct {
+		name   string
+		schema int
+		key    string
+		want   bool
+	}{
+		{name: "same schema hits", schema: 1, key: "git", want: true},
+		{name: "newer schema misses", schema: 2, key: "git", want: false},
+		{name: "unversioned entry misses", schema: 1, key: "legacy", want: false},
+		{name: "missing key misses", schema: 1, key: "missing", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info testInfo
+			found, err := NewVersionedCache(backing, tt.schema).Get(tt.key, &info)
+			if err != nil {
+				t.Fatalf("Get() error: %v", err)
+			}
+			if found != tt.want {
+				t.Errorf("Get() found = %v, want %v", found, tt.want)
+			}
+			if found && info.Branch != "main" {
+				t.Errorf("Get() Branch = %q, want main", info.Branch)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Package daemon serves status line renders over a Unix socket so invocations can
+// reuse a resident process with warm config, templates and caches.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// How long a client waits to connect before rendering in-process.
+	dialTimeout = 100 * time.Millisecond
+
+	// How long a client waits for the daemon to render before giving up.
+	requestTimeout = 2 * time.Second
+
+	// Largest request accepted by the server.
+	maxRequestBytes = 1 << 20
+)
+
+// Handler renders a status line for a raw Claude session JSON request.
+type Handler func(ctx context.Context, request []byte) (string, error)
+
+// response is sent back to the client for every request.
+type response struct {
+	Output string `json:"output"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ErrAlreadyRunning is returned by Listen when another daemon serves the socket.
+var ErrAlreadyRunning = errors.New("daemon already running")
+
+// DefaultSocketPath returns the per-user socket path:
+// $XDG_RUNTIME_DIR/ccstatus.sock, or ccstatus-<uid>/daemon.sock in the temp directory.
+func DefaultSocketPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "ccstatus.sock")
+	}
+	return filepath.Join(os.TempDir(), "ccstatus-"+strconv.Itoa(os.Getuid()), "daemon.sock")
+}
+
+// Listen creates the Unix socket at path, replacing a stale socket left by a daemon
+// that exited without cleaning up. The socket is only accessible to the current user,
+// and its directory must be the current user's with mode 0700.
+func Listen(path string) (net.Listener, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	if err := checkSocketDir(dir); err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if conn, dialErr := net.DialTimeout("unix", path, dialTimeout); dialErr == nil {
+			_ = conn.Close()
+			return nil, ErrAlreadyRunning
+		}
+		// Nobody is listening, remove the stale socket
+		_ = os.Remove(path)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(path, 0600); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+// Serve handles connections on listener until ctx is cancelled or the listener fails.
+// Each connection carries one request: the client writes the session JSON, closes its
+// write side and reads the JSON response. Serve waits for in-flight requests before returning.
+func Serve(ctx context.Context, listener net.Listener, handler Handler) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			serveConn(ctx, conn, handler)
+		}()
+	}
+}
+
+// serveConn handles a single request.
+func serveConn(ctx context.Context, conn net.Conn, handler Handler) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(requestTimeout))
+
+	request, err := io.ReadAll(io.LimitReader(conn, maxRequestBytes))
+	var resp response
+	if err == nil {
+		resp.Output, err = handler(ctx, request)
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+
+	_ = json.NewEncoder(conn).Encode(resp)
+}
+
+// Forward sends a request to the daemon at path and returns the rendered output.
+// Returns an error if no daemon is listening or it fails, so the caller can render in-process.
+// Like Listen, refuses a socket in a directory other users could have created.
+func Forward(path string, request []byte) (string, error) {
+	if err := checkSocketDir(filepath.Dir(path)); err != nil {
+		return "", err
+	}
+
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(requestTimeout))
+
+	if _, err = conn.Write(request); err != nil {
+		return "", err
+	}
+	if unixConn, ok := conn.(*net.UnixConn); ok {
+		if err = unixConn.CloseWrite(); err != nil {
+			return "", err
+		}
+	}
+
+	var resp response
+	if err = json.NewDecoder(conn).Decode(&resp); err != nil {
+		return "", fmt.Errorf("invalid daemon response: %w", err)
+	}
+	if resp.Error != "" {
+		return "", errors.New(resp.Error)
+	}
+	return resp.Output, nil
+}
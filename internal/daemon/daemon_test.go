@@ -0,0 +1,107 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// socketDir returns a temp directory private to the current user, as Listen requires.
+func socketDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Chmod(dir, 0700); err != nil {
+		t.Fatalf("Chmod() error: %v", err)
+	}
+	return dir
+}
+
+// startServer serves handler on a socket in a temp directory until the test ends.
+func startServer(t *testing.T, handler Handler) string {
+	t.Helper()
+	path := filepath.Join(socketDir(t), "d.sock")
+
+	listener, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- Serve(ctx, listener, handler) }()
+
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Serve() error: %v", err)
+		}
+	})
+	return path
+}
+
+func TestForward(t *testing.T) {
+	path := startServer(t, func(_ context.Context, request []byte) (string, error) {
+		if string(request) == "fail" {
+			return "", errors.New("render failed")
+		}
+		return "rendered " + string(request), nil
+	})
+
+	output, err := Forward(path, []byte(`{"session_id":"abc"}`))
+	if err != nil {
+		t.Fatalf("Forward() error: %v", err)
+	}
+	if want := `rendered {"session_id":"abc"}`; output != want {
+		t.Errorf("Forward() = %q, want %q", output, want)
+	}
+
+	if _, err = Forward(path, []byte("fail")); err == nil || err.Error() != "render failed" {
+		t.Errorf("Forward() error = %v, want handler error", err)
+	}
+}
+
+func TestForward_NoDaemon(t *testing.T) {
+	if _, err := Forward(filepath.Join(socketDir(t), "missing.sock"), []byte("{}")); err == nil {
+		t.Error("Forward() without daemon succeeded")
+	}
+}
+
+func TestListen_AlreadyRunning(t *testing.T) {
+	path := startServer(t, func(context.Context, []byte) (string, error) { return "", nil })
+
+	if _, err := Listen(path); !errors.Is(err, ErrAlreadyRunning) {
+		t.Errorf("Listen() error = %v, want ErrAlreadyRunning", err)
+	}
+}
+
+func TestListen_StaleSocket(t *testing.T) {
+	path := filepath.Join(socketDir(t), "d.sock")
+
+	// Leave a socket file behind with nobody listening
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("net.Listen() error: %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = stale.Close()
+	if _, err = os.Stat(path); err != nil {
+		t.Fatalf("stale socket missing: %v", err)
+	}
+
+	listener, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen() over stale socket error: %v", err)
+	}
+	defer listener.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("socket permissions = %o, want 600", perm)
+	}
+}
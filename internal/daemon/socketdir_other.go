@@ -0,0 +1,8 @@
+//go:build !unix
+
+package daemon
+
+// checkSocketDir is a no-op on platforms without Unix file ownership and modes.
+func checkSocketDir(string) error {
+	return nil
+}
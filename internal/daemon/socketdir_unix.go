@@ -0,0 +1,29 @@
+//go:build unix
+
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkSocketDir verifies that dir is a real directory owned by the current user and
+// closed to everyone else. Otherwise another user could have created it first, e.g. in
+// a shared /tmp, to receive the session JSON sent to the socket or answer in its place.
+func checkSocketDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("socket directory %s is not a directory", dir)
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); !ok || int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("socket directory %s is not owned by the current user", dir)
+	}
+	if info.Mode().Perm() != 0700 {
+		return fmt.Errorf("socket directory %s must have mode 0700, has %#o", dir, info.Mode().Perm())
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+//go:build unix
+
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListen_InsecureDir(t *testing.T) {
+	dir := socketDir(t)
+	// A directory others can write to, e.g. created by another user
+	if err := os.Chmod(dir, 0777); err != nil {
+		t.Fatalf("Chmod() error: %v", err)
+	}
+	path := filepath.Join(dir, "d.sock")
+
+	if listener, err := Listen(path); err == nil {
+		_ = listener.Close()
+		t.Error("Listen() in a world-writable directory succeeded")
+	}
+	if _, err := Forward(path, []byte("{}")); err == nil {
+		t.Error("Forward() in a world-writable directory succeeded")
+	}
+}
+
+func TestListen_SymlinkedDir(t *testing.T) {
+	base := socketDir(t)
+	target := filepath.Join(base, "target")
+	if err := os.Mkdir(target, 0700); err != nil {
+		t.Fatalf("Mkdir() error: %v", err)
+	}
+	link := filepath.Join(base, "link")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatalf("Symlink() error: %v", err)
+	}
+
+	if listener, err := Listen(filepath.Join(link, "d.sock")); err == nil {
+		_ = listener.Close()
+		t.Error("Listen() in a symlinked directory succeeded")
+	}
+}
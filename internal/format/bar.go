@@ -0,0 +1,58 @@
+package format
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	// Number of sub-cell steps available with Unicode eighth blocks.
+	eighthsPerCell = 8
+
+	barFull       = "█"
+	barEmpty      = "░"
+	barASCIIFull  = "#"
+	barASCIIEmpty = "-"
+)
+
+// eighthBlocks holds the partial cell glyphs indexed by eighths filled.
+var eighthBlocks = [eighthsPerCell]string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// Bar renders a fixed-width progress bar for a percentage (0-100).
+// Unicode bars use eighth blocks for sub-cell precision; ASCII bars round to whole cells.
+// Percentages outside 0-100 are clamped and NaN counts as 0. Returns empty string if width <= 0.
+// Examples (width 4):
+//
+//	50   -> "██░░"
+//	60   -> "██▍░"
+//	50 (ascii) -> "##--"
+func Bar(percentage float64, width int, ascii bool) string {
+	if width <= 0 {
+		return ""
+	}
+
+	if math.IsNaN(percentage) {
+		percentage = 0
+	}
+	percentage = math.Max(0, math.Min(100, percentage))
+
+	if ascii {
+		filled := int(math.Round(percentage / 100 * float64(width)))
+		return strings.Repeat(barASCIIFull, filled) + strings.Repeat(barASCIIEmpty, width-filled)
+	}
+
+	eighths := int(math.Round(percentage / 100 * float64(width*eighthsPerCell)))
+	full := eighths / eighthsPerCell
+	partial := eighths % eighthsPerCell
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(barFull, full))
+	empty := width - full
+	if partial > 0 {
+		b.WriteString(eighthBlocks[partial])
+		empty--
+	}
+	b.WriteString(strings.Repeat(barEmpty, empty))
+
+	return b.String()
+}
@@ -0,0 +1,33 @@
+package format
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBar(t *testing.T) {
+	tests := []struct {
+		name       string
+		percentage float64
+		width      int
+		ascii      bool
+		want       string
+	}{
+		{"half", 50, 4, false, "██░░"},
+		{"eighths", 60, 4, false, "██▍░"},
+		{"ascii", 50, 4, true, "##--"},
+		{"over 100 is clamped", 150, 4, false, "████"},
+		{"negative is clamped", -10, 4, true, "----"},
+		{"NaN counts as 0", math.NaN(), 4, false, "░░░░"},
+		{"NaN counts as 0 in ascii", math.NaN(), 4, true, "----"},
+		{"no width", 50, 0, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Bar(tt.percentage, tt.width, tt.ascii); got != tt.want {
+				t.Errorf("Bar(%v, %d, %v) = %q, want %q", tt.percentage, tt.width, tt.ascii, got, tt.want)
+			}
+		})
+	}
+}
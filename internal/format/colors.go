@@ -26,21 +26,26 @@ func Colorize(color Color, text string) string {
 	return string(color) + text + string(ColorReset)
 }
 
+// colorNames maps color names to Color constants.
+var colorNames = map[string]Color{
+	"red":     ColorRed,
+	"green":   ColorGreen,
+	"yellow":  ColorYellow,
+	"blue":    ColorBlue,
+	"magenta": ColorMagenta,
+	"cyan":    ColorCyan,
+	"gray":    ColorGray,
+	"grey":    ColorGray, // Alternative spelling
+}
+
 // ParseColor converts a color name string to a Color constant.
 // Returns ColorGray as default if the name is not recognized.
+// Components call it at construction and keep the result.
 func ParseColor(name string) Color {
-	colors := map[string]Color{
-		"red":     ColorRed,
-		"green":   ColorGreen,
-		"yellow":  ColorYellow,
-		"blue":    ColorBlue,
-		"magenta": ColorMagenta,
-		"cyan":    ColorCyan,
-		"gray":    ColorGray,
-		"grey":    ColorGray, // Alternative spelling
+	if color, ok := colorNames[name]; ok {
+		return color
 	}
-
-	if color, ok := colors[strings.ToLower(name)]; ok {
+	if color, ok := colorNames[strings.ToLower(name)]; ok {
 		return color
 	}
 	return ColorGray // Default to gray for unknown colors
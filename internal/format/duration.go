@@ -1,8 +1,6 @@
 package format
 
-import "fmt"
-
-// DurationMinutes formats minutes into a human-readable duration
+// DurationMinutes formats minutes into a human-readable duration using the default (English) locale
 // Examples:
 //
 //	45  -> "45m"
@@ -11,23 +9,10 @@ import "fmt"
 //	0   -> "0m"
 //	-5  -> "0m"
 func DurationMinutes(minutes int) string {
-	if minutes <= 0 {
-		return "0m"
-	}
-
-	if minutes < 60 {
-		return fmt.Sprintf("%dm", minutes)
-	}
-
-	hours := minutes / 60
-	mins := minutes % 60
-	if mins == 0 {
-		return fmt.Sprintf("%dh", hours)
-	}
-	return fmt.Sprintf("%dh%dm", hours, mins)
+	return Locale{}.DurationMinutes(minutes)
 }
 
-// DurationMs formats milliseconds into a human-readable duration
+// DurationMs formats milliseconds into a human-readable duration using the default (English) locale
 // Examples:
 //
 //	45000  -> "45s"
@@ -35,30 +20,5 @@ func DurationMinutes(minutes int) string {
 //	120000 -> "2m"
 //	3600000 -> "1h"
 func DurationMs(ms int64) string {
-	if ms <= 0 {
-		return "0s"
-	}
-
-	seconds := ms / 1000
-	if seconds < 60 {
-		return fmt.Sprintf("%ds", seconds)
-	}
-
-	minutes := seconds / 60
-	secs := seconds % 60
-
-	if minutes < 60 {
-		if secs == 0 {
-			return fmt.Sprintf("%dm", minutes)
-		}
-		return fmt.Sprintf("%dm%ds", minutes, secs)
-	}
-
-	hours := minutes / 60
-	mins := minutes % 60
-
-	if mins == 0 {
-		return fmt.Sprintf("%dh", hours)
-	}
-	return fmt.Sprintf("%dh%dm", hours, mins)
+	return Locale{}.DurationMs(ms)
 }
@@ -0,0 +1,110 @@
+package format
+
+import (
+	"regexp"
+	"strings"
+)
+
+// IconSet selects which glyphs logical icon names resolve to.
+type IconSet string
+
+// Supported icon sets.
+const (
+	IconSetNerdFont IconSet = "nerdfont" // Nerd Font patched glyphs (default)
+	IconSetEmoji    IconSet = "emoji"    // Standard Unicode emoji
+	IconSetASCII    IconSet = "ascii"    // Plain ASCII fallbacks
+)
+
+// iconGlyphs holds the glyph for one logical icon in every set.
+type iconGlyphs struct {
+	nerdFont string
+	emoji    string
+	ascii    string
+}
+
+// iconRegistry maps logical icon names to their glyphs.
+// Components reference these names as ":name:" in icon config values.
+var iconRegistry = map[string]iconGlyphs{
+	"model":         {nerdFont: "\uf2db", emoji: "🤖", ascii: "*"},       // nf-fa-microchip
+	"context":       {nerdFont: "\uea7b", emoji: "🧠", ascii: "ctx"},     // nf-cod-symbol_numeric
+	"folder":        {nerdFont: "\uf07b", emoji: "📁", ascii: "dir"},     // nf-fa-folder
+	"stopwatch":     {nerdFont: "\uf520", emoji: "⏱", ascii: "t"},       // nf-oct-stopwatch
+	"api":           {nerdFont: "\U000F1616", emoji: "🔌", ascii: "api"}, // nf-md-connection
+	"git_branch":    {nerdFont: "\ue725", emoji: "🌿", ascii: "git"},     // nf-dev-git_branch
+	"git_staged":    {nerdFont: "\uf05d", emoji: "✅", ascii: "+"},       // nf-fa-check_circle_o
+	"git_modified":  {nerdFont: "\uf044", emoji: "✏", ascii: "~"},       // nf-fa-pencil
+	"git_untracked": {nerdFont: "\uf420", emoji: "❓", ascii: "?"},       // nf-oct-question
+	"git_conflict":  {nerdFont: "\uf421", emoji: "❗", ascii: "!"},       // nf-oct-alert
+	"git_ahead":     {nerdFont: "\ueaa1", emoji: "⬆", ascii: "^"},       // nf-cod-arrow_up
+	"git_behind":    {nerdFont: "\uea9a", emoji: "⬇", ascii: "v"},       // nf-cod-arrow_down
+	"git_stash":     {nerdFont: "\uf48d", emoji: "📦", ascii: "$"},       // nf-oct-inbox
+	"git_state":     {nerdFont: "\ue727", emoji: "🔀", ascii: "op"},      // nf-dev-git_merge
+	"git_worktree":  {nerdFont: "\uf1bb", emoji: "🌳", ascii: "wt"},      // nf-fa-tree
+	"git_submodule": {nerdFont: "\uf414", emoji: "🧩", ascii: "sub"},     // nf-oct-file_submodule
+	"git_commit":    {nerdFont: "\uf417", emoji: "📝", ascii: "@"},       // nf-oct-git_commit
+	"git_diff":      {nerdFont: "\uf4d2", emoji: "📊", ascii: "diff"},    // nf-oct-diff
+	"git_tag":       {nerdFont: "\uf412", emoji: "🏷", ascii: "tag"},     // nf-oct-tag
+	"git_github":    {nerdFont: "\uf09b", emoji: "🐙", ascii: "gh"},      // nf-fa-github
+	"git_gitlab":    {nerdFont: "\uf296", emoji: "🦊", ascii: "gl"},      // nf-fa-gitlab
+	"git_bitbucket": {nerdFont: "\uf171", emoji: "🪣", ascii: "bb"},      // nf-fa-bitbucket
+	"git_gitea":     {nerdFont: "\uf0f4", emoji: "🍵", ascii: "gt"},      // nf-fa-coffee
+	"git_remote":    {nerdFont: "\uf233", emoji: "🌐", ascii: "remote"},  // nf-fa-server
+}
+
+// iconRefPattern matches ":name:" icon references.
+var iconRefPattern = regexp.MustCompile(`:([a-z0-9_]+):`)
+
+// ParseIconSet converts an icon set name to an IconSet constant.
+// Returns IconSetNerdFont as default if the name is not recognized.
+func ParseIconSet(name string) IconSet {
+	switch IconSet(strings.ToLower(name)) {
+	case IconSetEmoji:
+		return IconSetEmoji
+	case IconSetASCII:
+		return IconSetASCII
+	case IconSetNerdFont:
+		return IconSetNerdFont
+	default:
+		return IconSetNerdFont
+	}
+}
+
+// Icon returns the glyph for a logical icon name in this set.
+// The zero value IconSet behaves like IconSetNerdFont.
+func (s IconSet) Icon(name string) (string, bool) {
+	glyphs, ok := iconRegistry[name]
+	if !ok {
+		return "", false
+	}
+
+	switch s {
+	case IconSetEmoji:
+		return glyphs.emoji, true
+	case IconSetASCII:
+		return glyphs.ascii, true
+	case IconSetNerdFont:
+		return glyphs.nerdFont, true
+	default:
+		return glyphs.nerdFont, true
+	}
+}
+
+// ResolveIcons replaces ":name:" references in text with glyphs from this set.
+// Unknown names and literal glyphs are left untouched, so user-supplied icons keep working.
+// Examples (nerdfont):
+//
+//	":git_branch:"   -> ""
+//	":git_staged: "  -> " "
+//	"🎭"             -> "🎭"
+func (s IconSet) ResolveIcons(text string) string {
+	if !strings.Contains(text, ":") {
+		return text
+	}
+
+	return iconRefPattern.ReplaceAllStringFunc(text, func(ref string) string {
+		if glyph, ok := s.Icon(ref[1 : len(ref)-1]); ok {
+			return glyph
+		}
+		return ref
+	})
+}
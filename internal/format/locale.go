@@ -0,0 +1,273 @@
+package format
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Clock settings for Locale.Clock.
+const (
+	Clock12h = "12h"
+	Clock24h = "24h"
+)
+
+// Time layouts used when a component doesn't configure its own.
+const (
+	timeLayout12h        = "3:04 PM"
+	timeLayout24h        = "15:04"
+	weekdayTimeLayout12h = "Mon 3:04 PM"
+	weekdayTimeLayout24h = "Mon 15:04"
+)
+
+// NumberUnits holds suffixes for abbreviated large numbers.
+type NumberUnits struct {
+	Thousand string `yaml:"thousand"`
+	Million  string `yaml:"million"`
+	Billion  string `yaml:"billion"`
+}
+
+// DurationUnits holds suffixes for duration parts.
+type DurationUnits struct {
+	Day    string `yaml:"day"`
+	Hour   string `yaml:"hour"`
+	Minute string `yaml:"minute"`
+	Second string `yaml:"second"`
+}
+
+// Locale controls how numbers, times, durations and labels are rendered.
+// Empty fields fall back to English defaults, so the zero value is usable.
+type Locale struct {
+	// Decimal separator for fractional numbers (e.g. "." or ",")
+	DecimalSeparator string `yaml:"decimal_separator"`
+
+	// Default clock for times: "12h" or "24h"
+	Clock string `yaml:"clock"`
+
+	// Sign appended to percentages (e.g. "%" or " %")
+	PercentSign string `yaml:"percent_sign"`
+
+	// Suffixes for abbreviated numbers and duration parts
+	Units         NumberUnits   `yaml:"units"`
+	DurationUnits DurationUnits `yaml:"duration_units"`
+
+	// Abbreviated weekday names, Sunday first (replaces "Mon" in time layouts)
+	Weekdays []string `yaml:"weekdays"`
+
+	// Translated labels by key (e.g. "remaining", "resets")
+	Labels map[string]string `yaml:"labels"`
+}
+
+// englishLabels are the fallback labels for every locale.
+var englishLabels = map[string]string{
+	"remaining": "left",
+	"resets":    "resets",
+}
+
+// locales holds the built-in locales by lowercase name.
+var locales = map[string]Locale{
+	"en": {},
+	"en-gb": {
+		Clock: Clock24h,
+	},
+	"de": {
+		DecimalSeparator: ",",
+		Clock:            Clock24h,
+		PercentSign:      " %",
+		Units:            NumberUnits{Thousand: "k", Million: " Mio.", Billion: " Mrd."},
+		DurationUnits:    DurationUnits{Day: "T", Hour: "h", Minute: "m", Second: "s"},
+		Weekdays:         []string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		Labels:           map[string]string{"remaining": "übrig", "resets": "Reset"},
+	},
+	"fr": {
+		DecimalSeparator: ",",
+		Clock:            Clock24h,
+		PercentSign:      " %",
+		Units:            NumberUnits{Thousand: "k", Million: " M", Billion: " Md"},
+		DurationUnits:    DurationUnits{Day: "j", Hour: "h", Minute: "min", Second: "s"},
+		Weekdays:         []string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		Labels:           map[string]string{"remaining": "restant", "resets": "réinit."},
+	},
+	"es": {
+		DecimalSeparator: ",",
+		Clock:            Clock24h,
+		PercentSign:      " %",
+		Units:            NumberUnits{Thousand: "k", Million: " M", Billion: " MM"},
+		Weekdays:         []string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		Labels:           map[string]string{"remaining": "restante", "resets": "reinicio"},
+	},
+	"ja": {
+		Clock:         Clock24h,
+		DurationUnits: DurationUnits{Day: "日", Hour: "時間", Minute: "分", Second: "秒"},
+		Weekdays:      []string{"日", "月", "火", "水", "木", "金", "土"},
+		Labels:        map[string]string{"remaining": "残り", "resets": "リセット"},
+	},
+}
+
+// ParseLocale returns a copy of the built-in locale for a name such as "de" or "en_GB".
+// Region-specific names fall back to their language; unknown names return English.
+func ParseLocale(name string) Locale {
+	key := strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	loc, ok := locales[key]
+	if !ok {
+		if lang, _, found := strings.Cut(key, "-"); found {
+			loc = locales[lang]
+		}
+	}
+
+	// Copy reference fields so config overrides can't modify the built-in locale
+	loc.Weekdays = slices.Clone(loc.Weekdays)
+	loc.Labels = maps.Clone(loc.Labels)
+	return loc
+}
+
+// WithUnit formats a number with localized k/M/B suffixes.
+func (l Locale) WithUnit(value int64) string {
+	switch {
+	case value >= billion:
+		return l.Decimal(float64(value)/billion, 1) + orDefault(l.Units.Billion, "B")
+	case value >= million:
+		return l.Decimal(float64(value)/million, 1) + orDefault(l.Units.Million, "M")
+	case value >= 1000:
+		return strconv.FormatInt(value/1000, 10) + orDefault(l.Units.Thousand, "k")
+	default:
+		return strconv.FormatInt(value, 10)
+	}
+}
+
+// Decimal formats a number with the given precision and the locale's decimal separator.
+func (l Locale) Decimal(value float64, precision int) string {
+	s := strconv.FormatFloat(value, 'f', precision, 64)
+	if l.DecimalSeparator != "" && l.DecimalSeparator != "." {
+		s = strings.Replace(s, ".", l.DecimalSeparator, 1)
+	}
+	return s
+}
+
+// Percent formats a percentage as a whole number with the locale's percent sign.
+func (l Locale) Percent(value float64) string {
+	return l.Decimal(value, 0) + orDefault(l.PercentSign, "%")
+}
+
+// DurationMinutes formats minutes like "1h30m" using localized unit suffixes.
+func (l Locale) DurationMinutes(minutes int) string {
+	h, m := orDefault(l.DurationUnits.Hour, "h"), orDefault(l.DurationUnits.Minute, "m")
+	if minutes <= 0 {
+		return "0" + m
+	}
+
+	hours := minutes / 60
+	mins := minutes % 60
+	switch {
+	case hours == 0:
+		return strconv.Itoa(mins) + m
+	case mins == 0:
+		return strconv.Itoa(hours) + h
+	default:
+		return strconv.Itoa(hours) + h + strconv.Itoa(mins) + m
+	}
+}
+
+// DurationDays formats minutes like "2d3h" once a day is reached, otherwise like DurationMinutes.
+func (l Locale) DurationDays(minutes int) string {
+	const minutesPerDay = 24 * 60
+
+	days := minutes / minutesPerDay
+	if days <= 0 {
+		return l.DurationMinutes(minutes)
+	}
+
+	d, h := orDefault(l.DurationUnits.Day, "d"), orDefault(l.DurationUnits.Hour, "h")
+	hours := (minutes % minutesPerDay) / 60
+	if hours == 0 {
+		return strconv.Itoa(days) + d
+	}
+	return strconv.Itoa(days) + d + strconv.Itoa(hours) + h
+}
+
+// DurationMs formats milliseconds like "1m30s" using localized unit suffixes.
+func (l Locale) DurationMs(ms int64) string {
+	s := orDefault(l.DurationUnits.Second, "s")
+	if ms <= 0 {
+		return "0" + s
+	}
+
+	seconds := ms / 1000
+	if seconds < 60 {
+		return strconv.FormatInt(seconds, 10) + s
+	}
+
+	minutes := seconds / 60
+	secs := seconds % 60
+	if minutes < 60 {
+		m := orDefault(l.DurationUnits.Minute, "m")
+		if secs == 0 {
+			return strconv.FormatInt(minutes, 10) + m
+		}
+		return strconv.FormatInt(minutes, 10) + m + strconv.FormatInt(secs, 10) + s
+	}
+
+	return l.DurationMinutes(int(minutes))
+}
+
+// TimeLayout returns the default time layout for the locale's clock.
+func (l Locale) TimeLayout() string {
+	if l.Clock == Clock24h {
+		return timeLayout24h
+	}
+	return timeLayout12h
+}
+
+// WeekdayTimeLayout returns the default weekday and time layout for the locale's clock.
+func (l Locale) WeekdayTimeLayout() string {
+	if l.Clock == Clock24h {
+		return weekdayTimeLayout24h
+	}
+	return weekdayTimeLayout12h
+}
+
+// Time formats t in local time with the given Go layout, translating weekday names.
+// An empty layout uses TimeLayout.
+func (l Locale) Time(t time.Time, layout string) string {
+	if layout == "" {
+		layout = l.TimeLayout()
+	}
+
+	t = t.Local()
+	formatted := t.Format(layout)
+
+	if len(l.Weekdays) == int(time.Saturday)+1 && strings.Contains(layout, "Mon") {
+		formatted = strings.Replace(formatted, t.Format("Mon"), l.Weekdays[t.Weekday()], 1)
+	}
+	return formatted
+}
+
+// Label returns the translated label for key, falling back to English.
+func (l Locale) Label(key string) string {
+	if label, ok := l.Labels[key]; ok {
+		return label
+	}
+	return englishLabels[key]
+}
+
+// AllLabels returns all labels with English fallbacks for untranslated keys.
+func (l Locale) AllLabels() map[string]string {
+	labels := make(map[string]string, len(englishLabels))
+	for key := range englishLabels {
+		labels[key] = l.Label(key)
+	}
+	for key, label := range l.Labels {
+		labels[key] = label
+	}
+	return labels
+}
+
+// orDefault returns value, or fallback if value is empty.
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
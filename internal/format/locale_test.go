@@ -0,0 +1,195 @@
+package format
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		name          string
+		wantSeparator string
+		wantClock     string
+	}{
+		{"", "", ""},
+		{"en", "", ""},
+		{"en_GB", "", Clock24h},
+		{"en-gb", "", Clock24h},
+		{"de", ",", Clock24h},
+		{"DE", ",", Clock24h},
+		{"de_AT", ",", Clock24h}, // Region falls back to the language
+		{"fr-CA", ",", Clock24h},
+		{"xx", "", ""}, // Unknown falls back to English
+		{"xx_YY", "", ""},
+	}
+
+	for _, tt := range tests {
+		loc := ParseLocale(tt.name)
+		if loc.DecimalSeparator != tt.wantSeparator || loc.Clock != tt.wantClock {
+			t.Errorf("ParseLocale(%q) separator %q, clock %q, want %q, %q",
+				tt.name, loc.DecimalSeparator, loc.Clock, tt.wantSeparator, tt.wantClock)
+		}
+	}
+}
+
+func TestParseLocale_ReturnsCopy(t *testing.T) {
+	loc := ParseLocale("de")
+	loc.Weekdays[0] = "changed"
+	loc.Labels["resets"] = "changed"
+
+	fresh := ParseLocale("de")
+	if fresh.Weekdays[0] != "So" || fresh.Labels["resets"] != "Reset" {
+		t.Errorf("modifying a parsed locale changed the built-in one: %v %v", fresh.Weekdays, fresh.Labels)
+	}
+}
+
+func TestLocale_Decimal(t *testing.T) {
+	tests := []struct {
+		locale    string
+		value     float64
+		precision int
+		want      string
+	}{
+		{"en", 1.25, 1, "1.2"},
+		{"en", 3, 2, "3.00"},
+		{"de", 1.5, 1, "1,5"},
+		{"fr", 1234.5, 1, "1234,5"},
+		{"de", 42, 0, "42"},
+	}
+
+	for _, tt := range tests {
+		if got := ParseLocale(tt.locale).Decimal(tt.value, tt.precision); got != tt.want {
+			t.Errorf("%s Decimal(%v, %d) = %q, want %q", tt.locale, tt.value, tt.precision, got, tt.want)
+		}
+	}
+}
+
+func TestLocale_WithUnit(t *testing.T) {
+	tests := []struct {
+		locale string
+		value  int64
+		want   string
+	}{
+		{"en", 999, "999"},
+		{"en", 12_345, "12k"},
+		{"en", 1_500_000, "1.5M"},
+		{"en", 2_000_000_000, "2.0B"},
+		{"de", 1_500_000, "1,5 Mio."},
+		{"es", 3_000_000_000, "3,0 MM"},
+	}
+
+	for _, tt := range tests {
+		if got := ParseLocale(tt.locale).WithUnit(tt.value); got != tt.want {
+			t.Errorf("%s WithUnit(%d) = %q, want %q", tt.locale, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLocale_Percent(t *testing.T) {
+	if got := ParseLocale("en").Percent(42.4); got != "42%" {
+		t.Errorf("en Percent(42.4) = %q, want 42%%", got)
+	}
+	if got := ParseLocale("de").Percent(42.6); got != "43 %" {
+		t.Errorf("de Percent(42.6) = %q, want \"43 %%\"", got)
+	}
+}
+
+func TestLocale_DurationDays(t *testing.T) {
+	tests := []struct {
+		locale  string
+		minutes int
+		want    string
+	}{
+		{"en", 0, "0m"},
+		{"en", 45, "45m"},
+		{"en", 90, "1h30m"},
+		{"en", 24 * 60, "1d"},
+		{"en", 2*24*60 + 3*60 + 15, "2d3h"},
+		{"de", 2*24*60 + 3*60, "2T3h"},
+		{"fr", 90, "1h30min"},
+		{"fr", 24*60 + 60, "1j1h"},
+		{"ja", 2*24*60 + 5*60, "2日5時間"},
+		{"ja", 30, "30分"},
+	}
+
+	for _, tt := range tests {
+		if got := ParseLocale(tt.locale).DurationDays(tt.minutes); got != tt.want {
+			t.Errorf("%s DurationDays(%d) = %q, want %q", tt.locale, tt.minutes, got, tt.want)
+		}
+	}
+}
+
+func TestLocale_DurationMs(t *testing.T) {
+	tests := []struct {
+		locale string
+		ms     int64
+		want   string
+	}{
+		{"en", 0, "0s"},
+		{"en", 999, "0s"},
+		{"en", 45_000, "45s"},
+		{"en", 60_000, "1m"},
+		{"en", 90_000, "1m30s"},
+		{"en", 2 * 3_600_000, "2h"},
+		{"en", 3_600_000 + 30*60_000, "1h30m"},
+		{"fr", 90_000, "1min30s"},
+		{"ja", 90_000, "1分30秒"},
+		{"ja", 3_600_000 + 5*60_000, "1時間5分"},
+	}
+
+	for _, tt := range tests {
+		if got := ParseLocale(tt.locale).DurationMs(tt.ms); got != tt.want {
+			t.Errorf("%s DurationMs(%d) = %q, want %q", tt.locale, tt.ms, got, tt.want)
+		}
+	}
+}
+
+func TestLocale_Time(t *testing.T) {
+	monday := time.Date(2024, time.January, 15, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		locale string
+		layout string
+		want   string
+	}{
+		{"en", "", "2:30 PM"},
+		{"de", "", "14:30"},
+		{"en", "Mon 3:04 PM", "Mon 2:30 PM"},
+		{"de", "Mon 15:04", "Mo 14:30"},
+		{"fr", "Mon 15:04", "lun. 14:30"},
+		{"ja", "Mon 15:04", "月 14:30"},
+		{"de", "15:04", "14:30"}, // No weekday in the layout
+	}
+
+	for _, tt := range tests {
+		if got := ParseLocale(tt.locale).Time(monday, tt.layout); got != tt.want {
+			t.Errorf("%s Time(%q) = %q, want %q", tt.locale, tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestLocale_Time_IncompleteWeekdays(t *testing.T) {
+	// Overrides without all seven weekdays keep Go's English names
+	loc := Locale{Weekdays: []string{"So", "Mo"}}
+	monday := time.Date(2024, time.January, 15, 14, 30, 0, 0, time.Local)
+
+	if got := loc.Time(monday, "Mon 15:04"); got != "Mon 14:30" {
+		t.Errorf("Time() = %q, want \"Mon 14:30\"", got)
+	}
+}
+
+func TestLocale_Label(t *testing.T) {
+	de := ParseLocale("de")
+	if got := de.Label("resets"); got != "Reset" {
+		t.Errorf("de Label(resets) = %q, want Reset", got)
+	}
+
+	// Untranslated keys fall back to English
+	partial := Locale{Labels: map[string]string{"resets": "neu"}}
+	if got := partial.Label("remaining"); got != "left" {
+		t.Errorf("Label(remaining) = %q, want left", got)
+	}
+	if got := partial.AllLabels(); got["remaining"] != "left" || got["resets"] != "neu" {
+		t.Errorf("AllLabels() = %v, want English fallbacks with overrides", got)
+	}
+}
@@ -0,0 +1,38 @@
+package format
+
+import (
+	"math"
+	"strings"
+)
+
+// Sparkline levels from lowest to highest.
+var (
+	sparkLevels      = []rune("▁▂▃▄▅▆▇█")
+	sparkASCIILevels = []rune("_.-~=+*#")
+)
+
+// Sparkline renders values as a one-row chart scaled from 0 to maxValue.
+// Values outside the range are clamped. Returns empty string if there are no values.
+// Examples (maxValue 100):
+//
+//	[0 25 50 100]         -> "▁▃▅█"
+//	[0 25 50 100] (ascii) -> "_-=#"
+func Sparkline(values []float64, maxValue float64, ascii bool) string {
+	if len(values) == 0 || maxValue <= 0 {
+		return ""
+	}
+
+	levels := sparkLevels
+	if ascii {
+		levels = sparkASCIILevels
+	}
+	top := float64(len(levels) - 1)
+
+	var b strings.Builder
+	for _, v := range values {
+		ratio := math.Max(0, math.Min(1, v/maxValue))
+		b.WriteRune(levels[int(math.Round(ratio*top))])
+	}
+
+	return b.String()
+}
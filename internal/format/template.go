@@ -1,26 +1,78 @@
 package format
 
 import (
-	"bytes"
+	"strings"
+	"sync"
 	"text/template"
+
+	"github.com/mirage20/ccstatus-go/internal/text"
 )
 
-// RenderTemplate renders a template string with the given data.
-// Returns "[tpl-err]" on error to indicate template issues in the status line.
-func RenderTemplate(tmplStr string, data interface{}) string {
+// templateError is rendered in place of a template that fails to parse or execute.
+const templateError = "[tpl-err]"
+
+// Template is a parsed template, compiled once by components at construction.
+type Template struct {
+	tmpl  *template.Template
+	empty bool // Empty source renders as empty string
+}
+
+// funcs are the functions available to templates in addition to the text/template builtins.
+var funcs = template.FuncMap{
+	// truncate shortens s to n display columns with a trailing ellipsis: {{.Subject | truncate 30}}
+	"truncate": func(n int, s string) string {
+		return text.Truncate(s, n, text.TruncateEnd)
+	},
+}
+
+// templates caches parsed templates by source; nil marks invalid templates.
+// Long-running processes (the daemon) compile the same templates again on config reload.
+var templates sync.Map
+
+// CompileTemplate parses a template string, reusing earlier parses of the same source.
+// Invalid templates render as "[tpl-err]".
+func CompileTemplate(tmplStr string) *Template {
 	if tmplStr == "" {
+		return &Template{empty: true}
+	}
+	return &Template{tmpl: parseTemplate(tmplStr)}
+}
+
+// Render executes the template with the given data.
+// Returns "[tpl-err]" on error to indicate template issues in the status line.
+func (t *Template) Render(data any) string {
+	if t.empty {
 		return ""
 	}
+	if t.tmpl == nil {
+		return templateError // Invalid template syntax
+	}
 
-	tmpl, err := template.New("").Parse(tmplStr)
-	if err != nil {
-		return "[tpl-err]" // Invalid template syntax
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, data); err != nil {
+		return templateError // Template execution failed
 	}
 
-	var buf bytes.Buffer
-	if err = tmpl.Execute(&buf, data); err != nil {
-		return "[tpl-err]" // Template execution failed
+	return b.String()
+}
+
+// RenderTemplate renders a template string with the given data.
+// Returns "[tpl-err]" on error to indicate template issues in the status line.
+func RenderTemplate(tmplStr string, data interface{}) string {
+	return CompileTemplate(tmplStr).Render(data)
+}
+
+// parseTemplate returns the parsed template for tmplStr, parsing it on first use.
+// Returns nil if the template is invalid.
+func parseTemplate(tmplStr string) *template.Template {
+	if cached, ok := templates.Load(tmplStr); ok {
+		return cached.(*template.Template)
 	}
 
-	return buf.String()
+	tmpl, err := template.New("").Funcs(funcs).Parse(tmplStr)
+	if err != nil {
+		tmpl = nil
+	}
+	templates.Store(tmplStr, tmpl)
+	return tmpl
 }
@@ -9,19 +9,113 @@ import (
 const (
 	// Default cache TTL for git operations.
 	defaultCacheTTL = 10 * time.Second
+
+	// Default time allowed for reading the repository.
+	defaultTimeout = 500 * time.Millisecond
+
+	// Default remote read for git.remote.
+	defaultRemote = "origin"
+)
+
+// Backend selects how the provider reads repository information.
+type Backend string
+
+// Supported backends.
+const (
+	// BackendExec runs the git binary.
+	BackendExec Backend = "exec"
+	// BackendNative reads .git directly, without the git binary.
+	BackendNative Backend = "native"
+	// BackendAuto uses exec if git is installed, native otherwise.
+	BackendAuto Backend = "auto"
+)
+
+// UntrackedMode selects which untracked files are counted, like git's --untracked-files.
+type UntrackedMode string
+
+// Supported untracked modes.
+const (
+	// UntrackedNo counts no untracked files; git skips looking for them.
+	UntrackedNo UntrackedMode = "no"
+	// UntrackedNormal counts an untracked directory as one entry.
+	UntrackedNormal UntrackedMode = "normal"
+	// UntrackedAll counts every file in untracked directories.
+	UntrackedAll UntrackedMode = "all"
+)
+
+// IgnoreSubmodules selects which submodule changes are ignored, like git's --ignore-submodules.
+type IgnoreSubmodules string
+
+// Supported submodule ignore modes.
+const (
+	// IgnoreSubmodulesNone reports submodules with a changed commit, modified or untracked content.
+	IgnoreSubmodulesNone IgnoreSubmodules = "none"
+	// IgnoreSubmodulesUntracked ignores untracked content in submodules.
+	IgnoreSubmodulesUntracked IgnoreSubmodules = "untracked"
+	// IgnoreSubmodulesDirty only reports submodules with a changed commit.
+	IgnoreSubmodulesDirty IgnoreSubmodules = "dirty"
+	// IgnoreSubmodulesAll ignores submodules and skips looking into them.
+	IgnoreSubmodulesAll IgnoreSubmodules = "all"
 )
 
 // Config defines configuration for the git provider.
 type Config struct {
+	// Backend used to read the repository
+	Backend Backend `yaml:"backend"`
+
+	// Timeout for reading the repository; queries still running are reported in Info.TimedOut
+	Timeout time.Duration `yaml:"timeout"`
+
+	// UntrackedCache enables git's untracked cache for status (core.untrackedCache)
+	UntrackedCache bool `yaml:"untracked_cache"`
+
+	// FSMonitor enables git's builtin file system monitor for status (core.fsmonitor)
+	FSMonitor bool `yaml:"fsmonitor"`
+
+	// Commit reads the HEAD commit's hash, subject, author and time even when git.commit isn't active
+	Commit bool `yaml:"commit"`
+
+	// Diff reads staged and unstaged diff stats, which compares file contents, even when git.diff isn't active
+	Diff bool `yaml:"diff"`
+
+	// Tags enables reading the nearest tag, which walks history
+	Tags bool `yaml:"tags"`
+
+	// Remote whose URL is read while git.remote is active (empty = none)
+	Remote string `yaml:"remote"`
+
+	// Forges assigns forges to hosts not recognized by name
+	Forges []ForgeHost `yaml:"forges"`
+
+	// Untracked selects which untracked files are counted (empty = git's status.showUntrackedFiles)
+	Untracked UntrackedMode `yaml:"untracked"`
+
+	// IgnoreSubmodules selects which submodule changes are ignored (empty = git's config)
+	IgnoreSubmodules IgnoreSubmodules `yaml:"ignore_submodules"`
+
 	// Cache configuration
 	Cache core.CacheConfig `yaml:"cache"`
 }
 
+// ForgeHost assigns a forge to a host.
+// A list rather than a map, as config keys can't contain the dots in host names.
+type ForgeHost struct {
+	// Host name, e.g. "git.example.com"
+	Host string `yaml:"host"`
+
+	// Forge, one of the Forge constants
+	Forge string `yaml:"forge"`
+}
+
 // defaultConfig returns the default configuration for git provider.
 func defaultConfig() *Config {
 	return &Config{
+		Backend: BackendAuto,
+		Timeout: defaultTimeout,
+		Remote:  defaultRemote,
 		Cache: core.CacheConfig{
-			TTL: defaultCacheTTL,
+			TTL:   defaultCacheTTL,
+			Scope: core.CacheScopeWorkspace, // Share results between sessions in the same working tree
 		},
 	}
 }
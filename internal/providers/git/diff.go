@@ -0,0 +1,201 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// binaryProbeSize is how much of a file git checks for NUL bytes to decide it's binary.
+const binaryProbeSize = 8000
+
+// nativeDiffStats returns the stats of the staged (HEAD to index) and unstaged (index to
+// worktree) changes in status, like `git diff --cached --shortstat` and `git diff --shortstat`,
+// and the number of changed files, counting a file with both kinds of changes once.
+//
+// Like git, a submodule with modified content counts as a changed file without lines;
+// the changed submodules are passed in. The results match git's except that renames aren't
+// detected (a renamed file counts as deleted and added), and unmerged worktree files are
+// compared with our side only.
+// Returns zeros if ctx is done first.
+//
+//nolint:nonamedreturns // named returns document the meaning of each value
+func nativeDiffStats(
+	ctx context.Context, repo *gogit.Repository, head *object.Commit,
+	status gogit.Status, idx *index.Index, submodules []submoduleChange,
+) (staged, unstaged DiffStat, files int) {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return DiffStat{}, DiffStat{}, 0
+	}
+	root := worktree.Filesystem.Root()
+
+	var tree *object.Tree
+	if head != nil {
+		tree, _ = head.Tree()
+	}
+
+	// Unmerged paths are compared with our side, stage 2
+	entries := map[string]*index.Entry{}
+	for _, entry := range idx.Entries {
+		if entry.Stage == 0 || entry.Stage == index.OurMode {
+			entries[entry.Name] = entry
+		}
+	}
+	unmerged := unmergedPaths(idx)
+	changed := map[string]bool{}
+
+	for path, file := range status {
+		if ctx.Err() != nil {
+			return DiffStat{}, DiffStat{}, 0
+		}
+		if file.Staging == gogit.Untracked {
+			continue
+		}
+
+		entry := entries[path]
+		if file.Staging != gogit.Unmodified && !unmerged[path] {
+			staged.add(treeContent(repo, tree, path), indexContent(repo, entry))
+			changed[path] = true
+		}
+		if file.Worktree != gogit.Unmodified {
+			unstaged.add(indexContent(repo, entry), worktreeContent(root, path, entry))
+			changed[path] = true
+		}
+	}
+
+	for _, submodule := range submodules {
+		if submodule.modified && !submodule.newCommit {
+			unstaged.Files++
+			changed[submodule.path] = true
+		}
+	}
+
+	return staged, unstaged, len(changed)
+}
+
+// add counts a changed file with its content before and after the change.
+// Binary files count no lines.
+func (s *DiffStat) add(before, after []byte) {
+	s.Files++
+	if isBinary(before) || isBinary(after) {
+		return
+	}
+	for _, d := range diff.Do(string(before), string(after)) {
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			s.Insertions += countLines(d.Text)
+		case diffmatchpatch.DiffDelete:
+			s.Deletions += countLines(d.Text)
+		case diffmatchpatch.DiffEqual:
+		}
+	}
+}
+
+// isBinary reports whether content looks binary to git: a NUL byte near the start.
+func isBinary(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), binaryProbeSize)], 0) >= 0
+}
+
+// countLines returns the number of lines in text; a last line without newline counts too.
+func countLines(text string) int {
+	lines := strings.Count(text, "\n")
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		lines++
+	}
+	return lines
+}
+
+// submoduleContent is how git diffs a submodule: a single line with its commit.
+func submoduleContent(hash plumbing.Hash) []byte {
+	return []byte("Subproject commit " + hash.String() + "\n")
+}
+
+// treeContent returns the content of path in tree, or nil if it isn't there.
+func treeContent(repo *gogit.Repository, tree *object.Tree, path string) []byte {
+	if tree == nil {
+		return nil
+	}
+	entry, err := tree.FindEntry(path)
+	if err != nil {
+		return nil
+	}
+	if entry.Mode == filemode.Submodule {
+		return submoduleContent(entry.Hash)
+	}
+	return blobContent(repo, entry.Hash)
+}
+
+// indexContent returns the content staged in entry, or nil if there's no entry.
+func indexContent(repo *gogit.Repository, entry *index.Entry) []byte {
+	if entry == nil {
+		return nil
+	}
+	if entry.Mode == filemode.Submodule {
+		return submoduleContent(entry.Hash)
+	}
+	return blobContent(repo, entry.Hash)
+}
+
+// worktreeContent returns the content of path in the worktree at root, or nil if it's missing.
+// Symlinks are read as their target like git does; entry tells whether path is a submodule.
+func worktreeContent(root, path string, entry *index.Entry) []byte {
+	full := filepath.Join(root, filepath.FromSlash(path))
+	if entry != nil && entry.Mode == filemode.Submodule {
+		subRepo, err := gogit.PlainOpen(full)
+		if err != nil {
+			return nil
+		}
+		subHead, err := subRepo.Head()
+		if err != nil {
+			return nil
+		}
+		return submoduleContent(subHead.Hash())
+	}
+
+	stat, err := os.Lstat(full)
+	if err != nil {
+		return nil
+	}
+	if stat.Mode()&os.ModeSymlink != 0 {
+		target, linkErr := os.Readlink(full)
+		if linkErr != nil {
+			return nil
+		}
+		return []byte(target)
+	}
+	content, err := os.ReadFile(full)
+	if err != nil {
+		return nil
+	}
+	return content
+}
+
+// blobContent returns the content of a blob, or nil if it can't be read.
+func blobContent(repo *gogit.Repository, hash plumbing.Hash) []byte {
+	blob, err := repo.BlobObject(hash)
+	if err != nil {
+		return nil
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil
+	}
+	return content
+}
@@ -0,0 +1,95 @@
+package git
+
+import (
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Fingerprint returns a hash of the mtimes and sizes of the repository files that change
+// when HEAD moves, the index is updated, refs are written, the stash or the config changes
+// or an operation such as a rebase starts, advances or ends.
+// Working tree edits that don't touch the index aren't covered; the cache TTL bounds those.
+// Returns empty string outside a repository.
+func (p *Provider) Fingerprint() string {
+	gitDir, commonDir, ok := p.gitDirs()
+	if !ok {
+		return ""
+	}
+
+	h := fnv.New64a()
+	stamp := func(path string) {
+		h.Write([]byte(path))
+		if info, err := os.Stat(path); err == nil {
+			h.Write([]byte(strconv.FormatInt(info.ModTime().UnixNano(), 10)))
+			h.Write([]byte(strconv.FormatInt(info.Size(), 10)))
+		}
+	}
+
+	// Per-worktree state
+	stamp(filepath.Join(gitDir, "HEAD"))
+	stamp(filepath.Join(gitDir, "index"))
+	for _, name := range stateFiles {
+		stamp(filepath.Join(gitDir, name))
+	}
+
+	// Shared state: the config, packed refs, the stash reflog and loose refs.
+	// Git writes loose refs by renaming a lock file, which updates the directory mtime,
+	// so stamping the directories under refs is enough to notice ref changes.
+	stamp(filepath.Join(commonDir, "config"))
+	stamp(filepath.Join(commonDir, "packed-refs"))
+	stamp(filepath.Join(commonDir, "logs", "refs", "stash"))
+	_ = filepath.WalkDir(filepath.Join(commonDir, "refs"), func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			stamp(path)
+		}
+		return nil
+	})
+
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// gitDirs returns the repository's git directory and common directory.
+// They differ for linked worktrees, whose .git file points into the main repository.
+//
+//nolint:nonamedreturns // named returns tell the two directories apart
+func (p *Provider) gitDirs() (gitDir, commonDir string, ok bool) {
+	dotGit := filepath.Join(p.CacheWorkspace(), ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", "", false
+	}
+	if info.IsDir() {
+		return dotGit, dotGit, true
+	}
+
+	// Worktrees and submodules: .git is a file containing "gitdir: <path>"
+	content, err := os.ReadFile(dotGit)
+	if err != nil {
+		return "", "", false
+	}
+	gitDir, found := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+	if !found {
+		return "", "", false
+	}
+	gitDir = resolvePath(filepath.Dir(dotGit), strings.TrimSpace(gitDir))
+
+	// Linked worktrees share refs through the directory named in "commondir"
+	commonDir = gitDir
+	if common, readErr := os.ReadFile(filepath.Join(gitDir, "commondir")); readErr == nil {
+		commonDir = resolvePath(gitDir, strings.TrimSpace(string(common)))
+	}
+
+	return gitDir, commonDir, true
+}
+
+// resolvePath returns path, resolved against base if it's relative.
+func resolvePath(base, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(base, path)
+}
@@ -0,0 +1,114 @@
+package git
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mirage20/ccstatus-go/internal/cache/file"
+	"github.com/mirage20/ccstatus-go/internal/core"
+)
+
+func TestFingerprint_NotAGitRepo(t *testing.T) {
+	p := &Provider{workDir: t.TempDir()}
+	if got := p.Fingerprint(); got != "" {
+		t.Errorf("Fingerprint() = %q, want empty outside a repository", got)
+	}
+}
+
+func TestFingerprint_Changes(t *testing.T) {
+	dir := setupGitRepo(t)
+	createFile(t, dir, "README.md", "# Test")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "initial")
+
+	p := &Provider{workDir: dir}
+
+	tests := []struct {
+		name   string
+		change func()
+	}{
+		{name: "stage", change: func() {
+			createFile(t, dir, "staged.txt", "staged")
+			runGit(t, dir, "add", "staged.txt")
+		}},
+		{name: "commit", change: func() { runGit(t, dir, "commit", "-m", "second") }},
+		{name: "branch", change: func() { runGit(t, dir, "checkout", "-b", "feature") }},
+		{name: "stash", change: func() {
+			createFile(t, dir, "README.md", "# Modified")
+			runGit(t, dir, "stash")
+		}},
+		{name: "tag", change: func() { runGit(t, dir, "tag", "v1.0.0") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := p.Fingerprint()
+			if again := p.Fingerprint(); again != before {
+				t.Fatalf("Fingerprint() not stable: %q then %q", before, again)
+			}
+
+			tt.change()
+
+			if after := p.Fingerprint(); after == before {
+				t.Errorf("Fingerprint() unchanged after %s", tt.name)
+			}
+		})
+	}
+}
+
+func TestFingerprint_Worktree(t *testing.T) {
+	dir := setupGitRepo(t)
+	createFile(t, dir, "README.md", "# Test")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "initial")
+
+	worktree := filepath.Join(t.TempDir(), "wt")
+	runGit(t, dir, "worktree", "add", "-b", "wt", worktree)
+
+	p := &Provider{workDir: worktree}
+	gitDir, commonDir, ok := p.gitDirs()
+	if !ok {
+		t.Fatal("gitDirs() failed for linked worktree")
+	}
+	if want := filepath.Join(dir, ".git"); commonDir != want {
+		t.Errorf("commonDir = %q, want %q", commonDir, want)
+	}
+	if want := filepath.Join(dir, ".git", "worktrees", "wt"); gitDir != want {
+		t.Errorf("gitDir = %q, want %q", gitDir, want)
+	}
+
+	// A commit in the worktree updates the shared refs
+	before := p.Fingerprint()
+	createFile(t, worktree, "new.txt", "new")
+	runGit(t, worktree, "add", "new.txt")
+	runGit(t, worktree, "commit", "-m", "worktree")
+	if p.Fingerprint() == before {
+		t.Error("Fingerprint() unchanged after committing in worktree")
+	}
+}
+
+func TestFingerprint_InvalidatesCache(t *testing.T) {
+	dir := setupGitRepo(t)
+	createFile(t, dir, "README.md", "# Test")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "initial")
+
+	cached := core.NewCachingProvider(&Provider{workDir: dir}, file.NewCache(t.TempDir(), "session"),
+		time.Hour, func() any { return &Info{} })
+
+	result, _ := cached.Provide(context.Background())
+	if staged := result.(*Info).Staged; staged != 0 {
+		t.Fatalf("expected 0 staged files, got %d", staged)
+	}
+
+	// Staging must show up despite the long TTL
+	createFile(t, dir, "staged.txt", "staged")
+	runGit(t, dir, "add", "staged.txt")
+
+	result, _ = cached.Provide(context.Background())
+	if staged := result.(*Info).Staged; staged != 1 {
+		t.Errorf("expected 1 staged file after git add, got %d", staged)
+	}
+}
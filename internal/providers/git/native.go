@@ -0,0 +1,460 @@
+package git
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// nativeStatus reads repository information from .git without the git binary.
+// Returns an error outside a repository.
+func (p *Provider) nativeStatus(ctx context.Context) (*Info, error) {
+	repo, err := gogit.PlainOpenWithOptions(p.workDir, &gogit.PlainOpenOptions{
+		DetectDotGit:          true,
+		EnableDotGitCommonDir: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{IsRepo: true}
+
+	// HEAD is a branch, a detached commit or an unborn branch (no commits yet)
+	headRef, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.Head()
+	switch {
+	case err == nil && head.Name().IsBranch():
+		info.Branch = head.Name().Short()
+	case err == nil:
+		info.Branch = "@" + head.Hash().String()[:shortHashLength]
+	case errors.Is(err, plumbing.ErrReferenceNotFound) && headRef.Type() == plumbing.SymbolicReference:
+		info.Branch = headRef.Target().Short()
+	default:
+		return nil, err
+	}
+
+	var headCommit *object.Commit
+	if head != nil {
+		if commit, commitErr := repo.CommitObject(head.Hash()); commitErr == nil {
+			headCommit = commit
+		}
+	}
+	if p.commit && headCommit != nil {
+		info.CommitHash = head.Hash().String()[:shortHashLength]
+		// Like git's %s: the first paragraph, joined into one line
+		paragraph, _, _ := strings.Cut(strings.TrimSpace(headCommit.Message), "\n\n")
+		info.CommitSubject = strings.ReplaceAll(paragraph, "\n", " ")
+		info.CommitAuthor = headCommit.Author.Name
+		info.CommitTime = time.Unix(headCommit.Committer.When.Unix(), 0)
+	}
+
+	if p.tags && headCommit != nil {
+		nativeDescribe(ctx, repo, headCommit.Hash, info)
+		info.markTimedOut(ctx, QueryTag)
+	}
+	if head != nil && head.Name().IsBranch() {
+		info.Ahead, info.Behind, info.HasUpstream = nativeAheadBehind(ctx, repo, head)
+		info.markTimedOut(ctx, QueryUpstream)
+	}
+	if p.remote != "" {
+		p.parseRemote(info, nativeRemoteURL(repo, p.remote))
+	}
+	info.Stash = p.nativeStashCount()
+	var submodules []submoduleChange
+	if p.ignoreSubmodules != IgnoreSubmodulesAll {
+		submodules = nativeSubmoduleChanges(ctx, repo, p.ignoreSubmodules)
+		info.markTimedOut(ctx, QuerySubmodules)
+	}
+	for _, submodule := range submodules {
+		if submodule.newCommit {
+			info.SubmodulesOutOfDate++
+		}
+		if submodule.modified || submodule.untracked {
+			info.SubmodulesDirty++
+		}
+	}
+	status, idx := nativeWorktreeStatus(ctx, repo)
+	if status != nil && idx != nil {
+		if p.ignoreSubmodules == IgnoreSubmodulesAll {
+			status = removeSubmodules(status, idx)
+		}
+		p.nativeStatusCounts(info, status, idx, submodules)
+		if p.diff {
+			info.StagedDiff, info.UnstagedDiff, info.DiffFiles = nativeDiffStats(
+				ctx, repo, headCommit, status, idx, submodules)
+			info.markTimedOut(ctx, QueryDiff)
+		}
+	} else {
+		info.markTimedOut(ctx, QueryStatus)
+		if p.diff {
+			info.markTimedOut(ctx, QueryDiff)
+		}
+	}
+
+	return info, nil
+}
+
+// markTimedOut records queries in info.TimedOut if ctx is done, i.e. they may have stopped early.
+func (info *Info) markTimedOut(ctx context.Context, queries Query) {
+	if ctx.Err() != nil {
+		info.TimedOut |= queries
+	}
+}
+
+// worktreeScan is a go-git status scan of a worktree, shared by the calls that need it.
+type worktreeScan struct {
+	done   chan struct{} // Closed when the scan finished
+	status gogit.Status  // nil if the scan failed
+	idx    *index.Index  // The index status compared with
+}
+
+// worktreeScans are the scans in flight by worktree root. A scan outlives the call
+// that started it when ctx is done first; the daemon's later calls then join it
+// rather than pile up more scans of the same worktree.
+var worktreeScans = struct {
+	sync.Mutex
+	running map[string]*worktreeScan
+}{running: map[string]*worktreeScan{}}
+
+// nativeWorktreeStatus returns the status of the worktree and the index it was compared with.
+// Joins a scan of the same worktree already in flight. Returns nil if ctx is done first;
+// the scan then finishes in the background. The status is shared, so don't modify it.
+func nativeWorktreeStatus(ctx context.Context, repo *gogit.Repository) (gogit.Status, *index.Index) {
+	if ctx.Err() != nil {
+		return nil, nil
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, nil
+	}
+	root := worktree.Filesystem.Root()
+
+	worktreeScans.Lock()
+	scan, running := worktreeScans.running[root]
+	if !running {
+		scan = &worktreeScan{done: make(chan struct{})}
+		worktreeScans.running[root] = scan
+		go func() {
+			defer close(scan.done)
+			if idx, idxErr := repo.Storer.Index(); idxErr == nil {
+				if status, statusErr := worktree.Status(); statusErr == nil {
+					scan.status, scan.idx = status, idx
+				}
+			}
+			worktreeScans.Lock()
+			delete(worktreeScans.running, root)
+			worktreeScans.Unlock()
+		}()
+	}
+	worktreeScans.Unlock()
+
+	select {
+	case <-scan.done:
+		return scan.status, scan.idx
+	case <-ctx.Done():
+		return nil, nil
+	}
+}
+
+// nativeStatusCounts records counts of staged, modified, untracked, and conflicted files in info.
+// Like git, submodules with changes count as modified; go-git only notices a changed commit,
+// so the changed submodules are passed in.
+// go-git reports type changes (e.g. a file replaced by a symlink) as modifications.
+func (p *Provider) nativeStatusCounts(info *Info, status gogit.Status, idx *index.Index, submodules []submoduleChange) {
+	unmerged := unmergedPaths(idx)
+
+	var untracked []string
+	for path, file := range status {
+		switch {
+		case unmerged[path]:
+			// Counted below
+		case file.Staging == gogit.Untracked && file.Worktree == gogit.Untracked:
+			untracked = append(untracked, path)
+		default:
+			if file.Staging != gogit.Unmodified {
+				info.Staged++
+				info.IndexChanges.count(byte(file.Staging))
+			}
+			if file.Worktree != gogit.Unmodified {
+				info.Modified++
+				info.WorktreeChanges.count(byte(file.Worktree))
+			}
+		}
+	}
+
+	for _, submodule := range submodules {
+		if file, found := status[submodule.path]; !found || file.Worktree == gogit.Unmodified {
+			info.Modified++
+			info.WorktreeChanges.Modified++
+		}
+	}
+
+	switch p.untracked {
+	case UntrackedNo:
+	case UntrackedAll:
+		info.Untracked = len(untracked)
+	default:
+		info.Untracked = countUntrackedEntries(untracked, idx)
+	}
+	info.Conflicts = len(unmerged)
+}
+
+// countUntrackedEntries returns the number of untracked entries git shows by default:
+// a directory without tracked files counts once, rather than every file in it.
+func countUntrackedEntries(untracked []string, idx *index.Index) int {
+	// Directories containing tracked files
+	tracked := map[string]bool{}
+	for _, entry := range idx.Entries {
+		for dir := path.Dir(entry.Name); dir != "."; dir = path.Dir(dir) {
+			tracked[dir] = true
+		}
+	}
+
+	// Each file counts as its outermost directory without tracked files, or itself
+	entries := map[string]bool{}
+	for _, file := range untracked {
+		entry := file
+		for dir := path.Dir(file); dir != "." && !tracked[dir]; dir = path.Dir(dir) {
+			entry = dir + "/"
+		}
+		entries[entry] = true
+	}
+	return len(entries)
+}
+
+// removeSubmodules returns status without the submodules, to ignore their changes.
+func removeSubmodules(status gogit.Status, idx *index.Index) gogit.Status {
+	submodules := map[string]bool{}
+	for _, entry := range idx.Entries {
+		if entry.Mode == filemode.Submodule {
+			submodules[entry.Name] = true
+		}
+	}
+
+	result := make(gogit.Status, len(status))
+	for path, file := range status {
+		if !submodules[path] {
+			result[path] = file
+		}
+	}
+	return result
+}
+
+// unmergedPaths returns the paths with entries in stages 1-3 of the index.
+// Merged entries decode as stage 0 (go-git's index.Merged constant is 1, so compare to 0).
+func unmergedPaths(idx *index.Index) map[string]bool {
+	unmerged := map[string]bool{}
+	for _, entry := range idx.Entries {
+		if entry.Stage != 0 {
+			unmerged[entry.Name] = true
+		}
+	}
+	return unmerged
+}
+
+// submoduleChange describes how an initialized submodule differs from what's recorded.
+type submoduleChange struct {
+	path      string
+	newCommit bool // Another commit is checked out
+	modified  bool // Tracked content is modified
+	untracked bool // Untracked content exists
+}
+
+// nativeSubmoduleChanges returns the initialized submodules with changes.
+// Content changes are ignored like git's --ignore-submodules=untracked or dirty, if ignore says so.
+func nativeSubmoduleChanges(ctx context.Context, repo *gogit.Repository, ignore IgnoreSubmodules) []submoduleChange {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil
+	}
+	submodules, err := worktree.Submodules()
+	if err != nil {
+		return nil
+	}
+
+	var changes []submoduleChange
+	for _, submodule := range submodules {
+		if ctx.Err() != nil {
+			break
+		}
+
+		// Uninitialized submodules have no repository to compare with
+		subRepo, repoErr := submodule.Repository()
+		if repoErr != nil {
+			continue
+		}
+
+		change := submoduleChange{path: submodule.Config().Path}
+		if status, statusErr := submodule.Status(); statusErr == nil && !status.IsClean() {
+			change.newCommit = true
+		}
+		if subWorktree, wtErr := subRepo.Worktree(); wtErr == nil && ignore != IgnoreSubmodulesDirty {
+			if status, statusErr := subWorktree.Status(); statusErr == nil {
+				for _, file := range status {
+					if file.Staging == gogit.Untracked && file.Worktree == gogit.Untracked {
+						change.untracked = ignore != IgnoreSubmodulesUntracked
+					} else {
+						change.modified = true
+					}
+				}
+			}
+		}
+		if change.newCommit || change.modified || change.untracked {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// nativeAheadBehind returns commits ahead/behind the branch's upstream and whether it exists.
+// The upstream comes from the branch's remote and merge settings in git config.
+//
+//nolint:nonamedreturns // named returns document the meaning of each value
+func nativeAheadBehind(ctx context.Context, repo *gogit.Repository, head *plumbing.Reference) (ahead, behind int, hasUpstream bool) {
+	cfg, err := repo.Config()
+	if err != nil {
+		return 0, 0, false
+	}
+	branch, ok := cfg.Branches[head.Name().Short()]
+	if !ok || branch.Remote == "" || branch.Merge == "" {
+		return 0, 0, false
+	}
+
+	// "." tracks a local branch; otherwise the remote-tracking branch of the remote
+	upstreamName := branch.Merge
+	if branch.Remote != "." {
+		upstreamName = plumbing.NewRemoteReferenceName(branch.Remote, branch.Merge.Short())
+	}
+	upstream, err := repo.Reference(upstreamName, true)
+	if err != nil {
+		return 0, 0, false // Upstream is configured but gone
+	}
+
+	ahead, behind, err = countDivergence(ctx, repo, head.Hash(), upstream.Hash())
+	if err != nil {
+		return 0, 0, false
+	}
+	return ahead, behind, true
+}
+
+// Walk flags marking which side of the comparison reaches a commit.
+const (
+	reachedFromLeft  = 1 << iota // Reachable from HEAD
+	reachedFromRight             // Reachable from the upstream
+	reachedFromBoth  = reachedFromLeft | reachedFromRight
+)
+
+// countDivergence counts the commits reachable from only left and only right,
+// like `git rev-list --left-right --count left...right`.
+// Commits are walked newest first until every queued commit is reachable from both sides.
+//
+//nolint:nonamedreturns // named returns document the meaning of each count
+func countDivergence(ctx context.Context, repo *gogit.Repository, left, right plumbing.Hash) (ahead, behind int, err error) {
+	flags := map[plumbing.Hash]int{}
+	queue := &commitQueue{}
+
+	push := func(hash plumbing.Hash, flag int) error {
+		if flags[hash]|flag == flags[hash] {
+			return nil
+		}
+		flags[hash] |= flag
+		commit, commitErr := repo.CommitObject(hash)
+		if errors.Is(commitErr, plumbing.ErrObjectNotFound) {
+			return nil // Shallow clone boundary
+		}
+		if commitErr != nil {
+			return commitErr
+		}
+		heap.Push(queue, commit)
+		return nil
+	}
+
+	if err = push(left, reachedFromLeft); err != nil {
+		return 0, 0, err
+	}
+	if err = push(right, reachedFromRight); err != nil {
+		return 0, 0, err
+	}
+
+	for queue.Len() > 0 && !queue.allReachedFromBoth(flags) {
+		if err = ctx.Err(); err != nil {
+			return 0, 0, err
+		}
+		commit, _ := heap.Pop(queue).(*object.Commit)
+		for _, parent := range commit.ParentHashes {
+			if err = push(parent, flags[commit.Hash]); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+
+	for _, flag := range flags {
+		switch flag {
+		case reachedFromLeft:
+			ahead++
+		case reachedFromRight:
+			behind++
+		}
+	}
+	return ahead, behind, nil
+}
+
+// commitQueue is a heap of commits ordered newest first.
+type commitQueue []*object.Commit
+
+func (q commitQueue) Len() int { return len(q) }
+func (q commitQueue) Less(i, j int) bool {
+	return q[i].Committer.When.After(q[j].Committer.When)
+}
+func (q commitQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *commitQueue) Push(x any) {
+	commit, _ := x.(*object.Commit)
+	*q = append(*q, commit)
+}
+func (q *commitQueue) Pop() any {
+	old := *q
+	commit := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return commit
+}
+
+// allReachedFromBoth reports whether every queued commit is reachable from both sides,
+// so walking further can't change the counts.
+func (q commitQueue) allReachedFromBoth(flags map[plumbing.Hash]int) bool {
+	for _, commit := range q {
+		if flags[commit.Hash] != reachedFromBoth {
+			return false
+		}
+	}
+	return true
+}
+
+// nativeStashCount returns the number of stash entries from the stash reflog.
+func (p *Provider) nativeStashCount() int {
+	_, commonDir, ok := p.gitDirs()
+	if !ok {
+		return 0
+	}
+	content, err := os.ReadFile(filepath.Join(commonDir, "logs", "refs", "stash"))
+	if err != nil {
+		return 0
+	}
+	return bytes.Count(content, []byte("\n"))
+}
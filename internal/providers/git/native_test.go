@@ -0,0 +1,317 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+)
+
+// commitFile writes a file and commits it.
+func commitFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	createFile(t, dir, name, content)
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-m", "update "+name)
+}
+
+func TestProvider_NativeMatchesExec(t *testing.T) {
+	tests := []struct {
+		name             string
+		setup            func(t *testing.T) string
+		untracked        UntrackedMode
+		ignoreSubmodules IgnoreSubmodules
+	}{
+		{
+			name: "not a repo",
+			setup: func(t *testing.T) string {
+				return t.TempDir()
+			},
+		},
+		{
+			name: "clean",
+			setup: func(t *testing.T) string {
+				dir := setupGitRepo(t)
+				commitFile(t, dir, "README.md", "# Test")
+				return dir
+			},
+		},
+		{
+			name: "status counts",
+			setup: func(t *testing.T) string {
+				dir := setupGitRepo(t)
+				commitFile(t, dir, "README.md", "# Test")
+				commitFile(t, dir, "deleted.txt", "deleted")
+				createFile(t, dir, "untracked.txt", "untracked")
+				createFile(t, dir, ".gitignore", "*.log\n")
+				createFile(t, dir, "ignored.log", "ignored")
+				createFile(t, dir, "README.md", "# Modified")
+				createFile(t, dir, "staged.txt", "staged")
+				runGit(t, dir, "add", "staged.txt")
+				runGit(t, dir, "rm", "-q", "deleted.txt")
+				return dir
+			},
+		},
+		{
+			name: "diff stats",
+			setup: func(t *testing.T) string {
+				dir := setupGitRepo(t)
+				commitFile(t, dir, "README.md", "one\ntwo\nthree\n")
+				commitFile(t, dir, "deleted.txt", "a\nb\n")
+				commitFile(t, dir, "image.bin", "\x00\x01")
+				createFile(t, dir, "README.md", "one\n2\nthree\nfour")
+				createFile(t, dir, "image.bin", "\x00\x02")
+				createFile(t, dir, "staged.txt", "staged\n")
+				runGit(t, dir, "add", "staged.txt")
+				runGit(t, dir, "rm", "-q", "deleted.txt")
+				createFile(t, dir, "staged.txt", "staged\nthen changed\n")
+				return dir
+			},
+		},
+		{
+			name: "tags",
+			setup: func(t *testing.T) string {
+				dir := setupGitRepo(t)
+				commitFile(t, dir, "README.md", "# Test")
+				runGit(t, dir, "tag", "v0.9")
+				runGit(t, dir, "tag", "-a", "v1.0", "-m", "release 1.0")
+				runGit(t, dir, "checkout", "-q", "-b", "feature")
+				commitFile(t, dir, "feature.txt", "feature")
+				runGit(t, dir, "tag", "feature-1")
+				runGit(t, dir, "checkout", "-q", "-")
+				commitFile(t, dir, "main.txt", "main")
+				runGit(t, dir, "merge", "-q", "--no-edit", "feature")
+				commitFile(t, dir, "after.txt", "after")
+				return dir
+			},
+		},
+		{
+			name: "remote with insteadOf",
+			setup: func(t *testing.T) string {
+				dir := setupRemoteRepo(t)
+				runGit(t, dir, "remote", "add", "origin", "gh:mirage20/fork")
+				return dir
+			},
+		},
+		{
+			name: "detached head",
+			setup: func(t *testing.T) string {
+				dir := setupGitRepo(t)
+				commitFile(t, dir, "README.md", "# Test")
+				runGit(t, dir, "checkout", "-q", "--detach", "HEAD")
+				return dir
+			},
+		},
+		{
+			name: "conflict",
+			setup: func(t *testing.T) string {
+				dir := setupGitRepo(t)
+				commitFile(t, dir, "README.md", "# Initial")
+				runGit(t, dir, "checkout", "-q", "-b", "feature")
+				commitFile(t, dir, "README.md", "# Feature")
+				runGit(t, dir, "checkout", "-q", "-")
+				commitFile(t, dir, "README.md", "# Main")
+				cmd := exec.Command("git", "merge", "feature")
+				cmd.Dir = dir
+				_ = cmd.Run() // Merge fails due to the conflict
+				return dir
+			},
+		},
+		{
+			name: "ahead and behind",
+			setup: func(t *testing.T) string {
+				remoteDir := t.TempDir()
+				runGit(t, remoteDir, "init", "-q", "--bare")
+
+				dir := setupGitRepo(t)
+				commitFile(t, dir, "README.md", "# Test")
+				runGit(t, dir, "remote", "add", "origin", remoteDir)
+				runGit(t, dir, "push", "-q", "-u", "origin", "HEAD")
+
+				// Another clone pushes two commits, this one commits locally
+				other := t.TempDir()
+				runGit(t, other, "clone", "-q", remoteDir, ".")
+				runGit(t, other, "config", "user.email", "test@test.com")
+				runGit(t, other, "config", "user.name", "Test User")
+				commitFile(t, other, "a.txt", "a")
+				commitFile(t, other, "b.txt", "b")
+				runGit(t, other, "push", "-q")
+
+				commitFile(t, dir, "local.txt", "local")
+				runGit(t, dir, "fetch", "-q")
+				return dir
+			},
+		},
+		{
+			name: "stash",
+			setup: func(t *testing.T) string {
+				dir := setupGitRepo(t)
+				commitFile(t, dir, "README.md", "# Test")
+				createFile(t, dir, "README.md", "# Stashed")
+				runGit(t, dir, "stash", "push", "-q")
+				createFile(t, dir, "README.md", "# Stashed again")
+				runGit(t, dir, "stash", "push", "-q")
+				return dir
+			},
+		},
+		{
+			name: "submodules",
+			setup: func(t *testing.T) string {
+				dir := setupSubmoduleRepo(t)
+				createFile(t, filepath.Join(dir, "lib"), "scratch.txt", "scratch")
+				runGit(t, filepath.Join(dir, "vendor", "tool"), "checkout", "-q", "HEAD~1")
+				return dir
+			},
+		},
+		{
+			name:             "submodules ignoring untracked content",
+			setup:            setupChangedSubmodules,
+			ignoreSubmodules: IgnoreSubmodulesUntracked,
+		},
+		{
+			name:             "submodules ignoring dirty content",
+			setup:            setupChangedSubmodules,
+			ignoreSubmodules: IgnoreSubmodulesDirty,
+		},
+		{
+			name:             "submodules ignored",
+			setup:            setupChangedSubmodules,
+			ignoreSubmodules: IgnoreSubmodulesAll,
+		},
+		{
+			name:  "untracked directories",
+			setup: setupUntrackedFiles,
+		},
+		{
+			name:      "untracked files",
+			setup:     setupUntrackedFiles,
+			untracked: UntrackedAll,
+		},
+		{
+			name:      "untracked files not counted",
+			setup:     setupUntrackedFiles,
+			untracked: UntrackedNo,
+		},
+		{
+			name: "linked worktree",
+			setup: func(t *testing.T) string {
+				dir := setupGitRepo(t)
+				commitFile(t, dir, "README.md", "# Test")
+				linked := filepath.Join(t.TempDir(), "parallel")
+				runGit(t, dir, "worktree", "add", "-q", "-b", "parallel-work", linked)
+				return linked
+			},
+		},
+		{
+			name: "unborn branch",
+			setup: func(t *testing.T) string {
+				dir := setupGitRepo(t)
+				runGit(t, dir, "checkout", "-q", "-b", "fresh")
+				createFile(t, dir, "new.txt", "new")
+				return dir
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := tt.setup(t)
+
+			execProvider := &Provider{
+				workDir: dir, backend: BackendExec, commit: true, diff: true, tags: true, remote: "origin",
+				untracked: tt.untracked, ignoreSubmodules: tt.ignoreSubmodules,
+			}
+			nativeProvider := *execProvider
+			nativeProvider.backend = BackendNative
+
+			execResult, _ := execProvider.Provide(context.Background())
+			nativeResult, err := nativeProvider.Provide(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want, got := *execResult.(*Info), *nativeResult.(*Info)
+			if got != want {
+				t.Errorf("native backend = %+v, exec backend = %+v", got, want)
+			}
+		})
+	}
+}
+
+// setupChangedSubmodules creates a repository with submodules "lib", with modified and
+// untracked content, and "vendor/tool", with another commit checked out.
+func setupChangedSubmodules(t *testing.T) string {
+	t.Helper()
+	dir := setupSubmoduleRepo(t)
+	createFile(t, filepath.Join(dir, "lib"), "lib.txt", "changed")
+	createFile(t, filepath.Join(dir, "lib"), "scratch.txt", "scratch")
+	runGit(t, filepath.Join(dir, "vendor", "tool"), "checkout", "-q", "HEAD~1")
+	return dir
+}
+
+// setupUntrackedFiles creates a repository with untracked files at the top level, in a
+// directory with tracked files, and in nested directories without tracked files.
+func setupUntrackedFiles(t *testing.T) string {
+	t.Helper()
+	dir := setupGitRepo(t)
+	for _, sub := range []string{"src", "build/out", "build/tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+	}
+	commitFile(t, dir, "src/main.go", "package main")
+	createFile(t, dir, "notes.txt", "notes")
+	createFile(t, dir, "src/new.go", "package main")
+	createFile(t, dir, "build/out/a.bin", "a")
+	createFile(t, dir, "build/out/b.bin", "b")
+	createFile(t, dir, "build/tmp/c.tmp", "c")
+	return dir
+}
+
+func TestNativeWorktreeStatus_JoinsScanInFlight(t *testing.T) {
+	dir := setupGitRepo(t)
+	commitFile(t, dir, "README.md", "# Test")
+	repo, err := gogit.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("failed to open repo: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to open worktree: %v", err)
+	}
+
+	// Pretend a scan that timed out earlier is still running
+	root := worktree.Filesystem.Root()
+	scan := &worktreeScan{done: make(chan struct{})}
+	worktreeScans.Lock()
+	worktreeScans.running[root] = scan
+	worktreeScans.Unlock()
+	t.Cleanup(func() {
+		worktreeScans.Lock()
+		delete(worktreeScans.running, root)
+		worktreeScans.Unlock()
+	})
+
+	// A call timing out waits for it rather than starting another scan
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if status, _ := nativeWorktreeStatus(ctx, repo); status != nil {
+		t.Errorf("expected no status while the scan runs, got %v", status)
+	}
+
+	// Once it finishes, its result is returned
+	result := make(chan gogit.Status, 1)
+	go func() {
+		status, _ := nativeWorktreeStatus(context.Background(), repo)
+		result <- status
+	}()
+	scan.status = gogit.Status{"README.md": &gogit.FileStatus{Staging: gogit.Modified}}
+	close(scan.done)
+	if status := <-result; len(status) != 1 || status["README.md"] == nil {
+		t.Errorf("expected the in-flight scan's status, got %v", status)
+	}
+}
@@ -2,9 +2,11 @@ package git
 
 import (
 	"context"
+	"os"
 	"os/exec"
-	"strconv"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mirage20/ccstatus-go/internal/config"
@@ -12,28 +14,64 @@ import (
 )
 
 const (
-	// Timeout for git commands.
-	gitTimeout = 500 * time.Millisecond
+	// Schema version of Info in the cache; bump when Info changes.
+	schemaVersion = 11
 )
 
 func init() {
 	// Self-register with type factory
 	core.RegisterProvider(string(Key), New, func() any {
 		return &Info{}
-	})
+	}, schemaVersion)
 }
 
 // Provider provides git repository information.
 type Provider struct {
 	workDir string
+	backend Backend       // Empty means BackendExec
+	timeout time.Duration // Zero means defaultTimeout
+	commit  bool          // Read the HEAD commit
+	diff    bool          // Read diff stats
+	tags    bool          // Read the nearest tag
+	remote  string        // Remote whose URL is read; empty reads none
+	forges  []ForgeHost
+
+	untracked        UntrackedMode    // Empty means git's setting
+	ignoreSubmodules IgnoreSubmodules // Empty means git's setting
+	untrackedCache   bool
+	fsmonitor        bool
 }
 
+// gitInstalled reports whether the git binary is on PATH, checked once per process.
+var gitInstalled = sync.OnceValue(func() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+})
+
 // New creates a new git provider with config.
 func New(cfgReader *config.Reader, session *core.ClaudeSession) (core.Provider, core.CacheConfig) {
 	cfg := config.GetProvider(cfgReader, "git", defaultConfig())
 
+	// Only git.remote shows the remote, so skip the extra lookup without it
+	remote := ""
+	if config.IsActive(cfgReader, "git.remote") {
+		remote = cfg.Remote
+	}
+
 	return &Provider{
 		workDir: session.Workspace.CurrentDir,
+		backend: cfg.Backend,
+		timeout: cfg.Timeout,
+		commit:  cfg.Commit || config.IsActive(cfgReader, "git.commit"),
+		diff:    cfg.Diff || config.IsActive(cfgReader, "git.diff"),
+		tags:    cfg.Tags,
+		remote:  remote,
+		forges:  cfg.Forges,
+
+		untracked:        cfg.Untracked,
+		ignoreSubmodules: cfg.IgnoreSubmodules,
+		untrackedCache:   cfg.UntrackedCache,
+		fsmonitor:        cfg.FSMonitor,
 	}, cfg.Cache
 }
 
@@ -42,6 +80,31 @@ func (p *Provider) Key() core.ProviderKey {
 	return Key
 }
 
+// CacheWorkspace returns the working tree root containing workDir, so every session
+// in the same repository shares cached results. Falls back to workDir outside a repository.
+func (p *Provider) CacheWorkspace() string {
+	if p.workDir == "" {
+		return ""
+	}
+
+	dir, err := filepath.Abs(p.workDir)
+	if err != nil {
+		return p.workDir
+	}
+
+	// Walk up looking for .git (a directory, or a file in worktrees and submodules)
+	for d := dir; ; {
+		if _, err = os.Stat(filepath.Join(d, ".git")); err == nil {
+			return d
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
+
 // gitCmd creates a git command with --no-optional-locks flag to prevent lock contention
 // with concurrent git operations (e.g., Claude Code running git commands).
 func (p *Provider) gitCmd(ctx context.Context, args ...string) *exec.Cmd {
@@ -53,145 +116,211 @@ func (p *Provider) gitCmd(ctx context.Context, args ...string) *exec.Cmd {
 
 // Provide returns git repository information.
 func (p *Provider) Provide(ctx context.Context) (any, error) {
-	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	timeout := p.timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	info := &Info{}
+	status := p.execStatus
+	if p.useNative() {
+		status = p.nativeStatus
+	}
 
-	// Check if it's a git repo by getting the branch
-	branch, err := p.getBranch(ctx)
+	info, err := status(ctx)
 	if err != nil {
 		// Not a git repo or git not available - return empty info (not an error)
-		return info, nil
+		return &Info{}, nil
 	}
 
-	info.IsRepo = true
-	info.Branch = branch
-
-	// Get status counts (staged, modified, untracked, conflicts)
-	info.Staged, info.Modified, info.Untracked, info.Conflicts = p.getStatusCounts(ctx)
-
-	// Get ahead/behind (only if we have an upstream)
-	info.Ahead, info.Behind, info.HasUpstream = p.getAheadBehind(ctx)
-
-	// Get stash count
-	info.Stash = p.getStashCount(ctx)
+	// Operations in progress and worktrees are only recorded in files under .git
+	p.readState(info)
+	p.readWorktree(info)
 
 	return info, nil
 }
 
-// getBranch returns the current branch name or "@<hash>" for detached HEAD.
-func (p *Provider) getBranch(ctx context.Context) (string, error) {
-	// Try to get branch name
-	cmd := p.gitCmd(ctx, "rev-parse", "--abbrev-ref", "HEAD")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
+// execStatus reads repository information by running git.
+// Returns an error outside a repository.
+func (p *Provider) execStatus(ctx context.Context) (*Info, error) {
+	// Read the HEAD commit and diff stats while status runs, if enabled; log fails before the first commit
+	var commit, staged, unstaged <-chan gitResult
+	if p.commit {
+		commit = p.startGit(ctx, "log", "-1", "--no-show-signature", "--format="+commitFormat, "HEAD")
 	}
-
-	branch := strings.TrimSpace(string(output))
-
-	// If detached HEAD, get short commit hash instead
-	if branch == "HEAD" {
-		return p.getShortHash(ctx)
+	if p.diff {
+		// Unmerged paths are listed once more without lines; --shortstat skips them too
+		diffArgs := append(p.statusConfigArgs(), "diff", "--numstat", "-z", "--diff-filter=u")
+		diffArgs = append(diffArgs, p.ignoreSubmodulesArgs()...)
+		staged = p.startGit(ctx, append(diffArgs, "--cached")...)
+		unstaged = p.startGit(ctx, diffArgs...)
 	}
-
-	return branch, nil
-}
-
-// getShortHash returns the short commit hash prefixed with "@".
-func (p *Provider) getShortHash(ctx context.Context) (string, error) {
-	cmd := p.gitCmd(ctx, "rev-parse", "--short", "HEAD")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
+	var remote <-chan gitResult
+	if p.remote != "" {
+		remote = p.startGit(ctx, "ls-remote", "--get-url", p.remote)
+	}
+	var describe <-chan gitResult
+	if p.tags {
+		describe = p.startGit(ctx, "describe", "--tags", "--long")
 	}
 
-	return "@" + strings.TrimSpace(string(output)), nil
-}
+	// A detached HEAD shows git's abbreviated hash, which grows with the repository;
+	// the commit query reads it anyway
+	var abbrev <-chan gitResult
+	head, _ := p.readHeadFile()
+	detached := head != "" && !strings.HasPrefix(head, "ref: ")
+	if detached && !p.commit {
+		abbrev = p.startGit(ctx, "rev-parse", "--short", "HEAD")
+	}
 
-// getStatusCounts returns counts of staged, modified, untracked, and conflicted files.
-//
-//nolint:nonamedreturns // named returns document the meaning of each count
-func (p *Provider) getStatusCounts(ctx context.Context) (staged, modified, untracked, conflicts int) {
-	cmd := p.gitCmd(ctx, "status", "--porcelain")
-	output, err := cmd.Output()
-	if err != nil {
-		return 0, 0, 0, 0
+	// A single status call reports branch, upstream, stash and file states (--show-stash needs git 2.35+)
+	args := append(p.statusConfigArgs(), "status", "--porcelain=v2", "--branch", "--show-stash")
+	if p.untracked != "" {
+		args = append(args, "--untracked-files="+string(p.untracked))
 	}
+	cmd := p.gitCmd(ctx, append(args, p.ignoreSubmodulesArgs()...)...)
+	output, err := cmd.Output()
 
-	for line := range strings.SplitSeq(strings.TrimSuffix(string(output), "\n"), "\n") {
-		if len(line) < 2 { //nolint:mnd // porcelain format has 2 char prefix
-			continue
+	var info *Info
+	switch {
+	case err == nil:
+		info = parseStatus(string(output))
+		if info.Stash == 0 {
+			// Git before 2.35 prints no stash header
+			info.Stash = p.nativeStashCount()
 		}
-		// Porcelain format: XY filename
-		// X = staged status, Y = unstaged status
-		x, y := line[0], line[1]
-
-		// Untracked: both X and Y are '?'
-		if x == '?' && y == '?' {
-			untracked++
-			continue
+	case ctx.Err() != nil:
+		// Timed out: still show the branch, with everything status reports unknown
+		if info = p.readHead(); info == nil {
+			return nil, err
 		}
+		info.TimedOut |= QueryStatus | QueryUpstream | QueryStash | QuerySubmodules
+	default:
+		return nil, err
+	}
 
-		// Conflicts: U in either position, or DD/AA/AU/UA/DU/UD combinations
-		if x == 'U' || y == 'U' || (x == 'D' && y == 'D') || (x == 'A' && y == 'A') {
-			conflicts++
-			continue
+	if commit != nil {
+		parseCommit(info, info.wait(QueryCommit, commit))
+	}
+	if detached {
+		hash := info.CommitHash
+		if abbrev != nil {
+			hash = strings.TrimSpace(string((<-abbrev).output))
 		}
-
-		// Staged: X is not ' ' (already handled '?' above)
-		if x != ' ' {
-			staged++
+		if hash != "" {
+			info.Branch = "@" + hash
 		}
+	}
+	if p.diff {
+		var stagedPaths, unstagedPaths []string
+		info.StagedDiff, stagedPaths = parseNumstat(info.wait(QueryDiff, staged))
+		info.UnstagedDiff, unstagedPaths = parseNumstat(info.wait(QueryDiff, unstaged))
+		info.DiffFiles = countDistinct(stagedPaths, unstagedPaths)
+	}
+	if describe != nil {
+		parseDescribe(info, info.wait(QueryTag, describe))
+	}
 
-		// Modified (unstaged): Y is 'M', 'D', etc. (not ' ')
-		if y != ' ' {
-			modified++
+	// For an unknown remote, ls-remote echoes the name
+	if remote != nil {
+		if remoteURL := strings.TrimSpace(info.wait(QueryRemote, remote)); remoteURL != p.remote {
+			p.parseRemote(info, remoteURL)
 		}
 	}
-
-	return staged, modified, untracked, conflicts
+	return info, nil
 }
 
-// getAheadBehind returns commits ahead/behind upstream and whether upstream exists.
-//
-//nolint:nonamedreturns // named returns document the meaning of each value
-func (p *Provider) getAheadBehind(ctx context.Context) (ahead, behind int, hasUpstream bool) {
-	// Check if upstream exists
-	cmd := p.gitCmd(ctx, "rev-parse", "--abbrev-ref", "@{upstream}")
-	if err := cmd.Run(); err != nil {
-		return 0, 0, false
+// readHead returns Info with the branch read from the HEAD file, for when status timed out.
+// Returns nil outside a repository.
+func (p *Provider) readHead() *Info {
+	head, ok := p.readHeadFile()
+	if !ok {
+		return nil
 	}
 
-	// Get ahead/behind counts
-	cmd = p.gitCmd(ctx, "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
-	output, err := cmd.Output()
-	if err != nil {
-		return 0, 0, false
+	info := &Info{IsRepo: true}
+	if ref, isRef := strings.CutPrefix(head, "ref: "); isRef {
+		info.Branch = strings.TrimPrefix(ref, "refs/heads/")
+	} else {
+		info.Branch = "@" + head[:min(len(head), shortHashLength)]
 	}
+	return info
+}
 
-	parts := strings.Fields(strings.TrimSpace(string(output)))
-	if len(parts) == 2 { //nolint:mnd // expected format: "ahead behind"
-		ahead, _ = strconv.Atoi(parts[0])
-		behind, _ = strconv.Atoi(parts[1])
+// readHeadFile returns the contents of the HEAD file: "ref: refs/heads/<branch>",
+// or a commit hash when detached. Returns false outside a repository.
+func (p *Provider) readHeadFile() (string, bool) {
+	gitDir, _, ok := p.gitDirs()
+	if !ok {
+		return "", false
 	}
+	content, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(content)), true
+}
 
-	return ahead, behind, true
+// statusConfigArgs returns git config overrides that speed up status and diff, if enabled.
+func (p *Provider) statusConfigArgs() []string {
+	var args []string
+	if p.untrackedCache {
+		args = append(args, "-c", "core.untrackedCache=true")
+	}
+	if p.fsmonitor {
+		args = append(args, "-c", "core.fsmonitor=true")
+	}
+	return args
 }
 
-// getStashCount returns the number of stash entries.
-func (p *Provider) getStashCount(ctx context.Context) int {
-	cmd := p.gitCmd(ctx, "stash", "list")
-	output, err := cmd.Output()
-	if err != nil {
-		return 0
+// ignoreSubmodulesArgs returns the --ignore-submodules option for status and diff, if configured.
+func (p *Provider) ignoreSubmodulesArgs() []string {
+	if p.ignoreSubmodules == "" {
+		return nil
 	}
+	return []string{"--ignore-submodules=" + string(p.ignoreSubmodules)}
+}
 
-	trimmed := strings.TrimSpace(string(output))
-	if trimmed == "" {
-		return 0
+// gitResult is the outcome of a git command run in the background.
+type gitResult struct {
+	output   []byte // nil if the command failed
+	timedOut bool   // The command was stopped by the timeout
+}
+
+// startGit runs a git command in the background.
+// The returned channel receives its output, or nothing (nil) if it fails.
+func (p *Provider) startGit(ctx context.Context, args ...string) <-chan gitResult {
+	result := make(chan gitResult, 1)
+	go func() {
+		output, err := p.gitCmd(ctx, args...).Output()
+		if err != nil {
+			result <- gitResult{timedOut: ctx.Err() != nil}
+			return
+		}
+		result <- gitResult{output: output}
+	}()
+	return result
+}
+
+// wait returns the output of a git command started with startGit,
+// recording query in info.TimedOut if the command timed out.
+func (info *Info) wait(query Query, result <-chan gitResult) string {
+	r := <-result
+	if r.timedOut {
+		info.TimedOut |= query
 	}
+	return string(r.output)
+}
 
-	return len(strings.Split(trimmed, "\n"))
+// useNative reports whether the native backend reads the repository.
+func (p *Provider) useNative() bool {
+	switch p.backend {
+	case BackendNative:
+		return true
+	case BackendAuto:
+		return !gitInstalled()
+	default:
+		return false
+	}
 }
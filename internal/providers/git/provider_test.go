@@ -5,7 +5,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 // setupGitRepo creates a temporary git repository for testing.
@@ -136,6 +138,24 @@ func TestProvider_DetachedHead(t *testing.T) {
 	}
 }
 
+func TestProvider_DetachedHeadAbbrev(t *testing.T) {
+	dir := setupGitRepo(t)
+	commitFile(t, dir, "README.md", "# Test")
+	runGit(t, dir, "checkout", "--detach", "HEAD")
+
+	// Like git, the hash is abbreviated to core.abbrev, which grows with the repository by default
+	runGit(t, dir, "config", "core.abbrev", "12")
+	want := "@" + strings.TrimSpace(runGit(t, dir, "rev-parse", "--short", "HEAD"))
+
+	for _, commit := range []bool{false, true} {
+		p := &Provider{workDir: dir, backend: BackendExec, commit: commit}
+		result, _ := p.Provide(context.Background())
+		if got := result.(*Info).Branch; got != want {
+			t.Errorf("with commit %v: expected branch %q, got %q", commit, want, got)
+		}
+	}
+}
+
 func TestProvider_StatusCounts(t *testing.T) {
 	dir := setupGitRepo(t)
 
@@ -298,3 +318,219 @@ func TestProvider_Stash(t *testing.T) {
 		t.Errorf("expected 2 stash entries, got %d", info.Stash)
 	}
 }
+
+func TestProvider_CacheWorkspace(t *testing.T) {
+	dir := setupGitRepo(t)
+	sub := filepath.Join(dir, "a", "b")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("failed to create subdirectory: %v", err)
+	}
+
+	// Sessions in any subdirectory share the repository root as workspace
+	for _, workDir := range []string{dir, sub} {
+		p := &Provider{workDir: workDir}
+		if got := p.CacheWorkspace(); got != dir {
+			t.Errorf("CacheWorkspace() for %s = %q, want %q", workDir, got, dir)
+		}
+	}
+}
+
+func TestProvider_Commit(t *testing.T) {
+	dir := setupGitRepo(t)
+
+	createFile(t, dir, "README.md", "# Test")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "Add readme\n\nWith a body")
+
+	// Off unless enabled
+	result, _ := (&Provider{workDir: dir}).Provide(context.Background())
+	if info := result.(*Info); info.CommitHash != "" {
+		t.Errorf("expected no CommitHash without the commit option, got %q", info.CommitHash)
+	}
+
+	before := time.Now().Add(-time.Minute)
+	p := &Provider{workDir: dir, commit: true}
+	result, _ = p.Provide(context.Background())
+	info := result.(*Info)
+
+	hash := strings.TrimSpace(runGit(t, dir, "rev-parse", "--short", "HEAD"))
+	if info.CommitHash != hash {
+		t.Errorf("expected CommitHash %q, got %q", hash, info.CommitHash)
+	}
+	if info.CommitSubject != "Add readme" {
+		t.Errorf("expected CommitSubject 'Add readme', got %q", info.CommitSubject)
+	}
+	if info.CommitAuthor != "Test User" {
+		t.Errorf("expected CommitAuthor 'Test User', got %q", info.CommitAuthor)
+	}
+	if info.CommitTime.Before(before) {
+		t.Errorf("expected recent CommitTime, got %v", info.CommitTime)
+	}
+}
+
+func TestNew_CommitFollowsComponent(t *testing.T) {
+	if p := newConfigured(t, "active: [git.branch]\n"); p.commit {
+		t.Error("expected no commit query without git.commit")
+	}
+	if p := newConfigured(t, "active: [git.commit]\n"); !p.commit {
+		t.Error("expected the commit query with git.commit active")
+	}
+	if p := newConfigured(t, "providers:\n  git:\n    commit: true\n"); !p.commit {
+		t.Error("expected the commit query with providers.git.commit")
+	}
+}
+
+func TestNew_DiffFollowsComponent(t *testing.T) {
+	if p := newConfigured(t, "active: [git.branch]\n"); p.diff {
+		t.Error("expected no diff query without git.diff")
+	}
+	if p := newConfigured(t, "active: [git.diff]\n"); !p.diff {
+		t.Error("expected the diff query with git.diff active")
+	}
+	if p := newConfigured(t, "providers:\n  git:\n    diff: true\n"); !p.diff {
+		t.Error("expected the diff query with providers.git.diff")
+	}
+}
+
+func TestProvider_DiffStats(t *testing.T) {
+	dir := setupGitRepo(t)
+	commitFile(t, dir, "README.md", "one\ntwo\nthree\n")
+
+	// Staged: a new two-line file. Unstaged: one line changed, one added, and one
+	// line added to the staged file.
+	createFile(t, dir, "new.txt", "a\nb\n")
+	runGit(t, dir, "add", "new.txt")
+	createFile(t, dir, "new.txt", "a\nb\nc\n")
+	createFile(t, dir, "README.md", "one\n2\nthree\nfour\n")
+	createFile(t, dir, "untracked.txt", "not counted\n")
+
+	result, _ := (&Provider{workDir: dir, diff: true}).Provide(context.Background())
+	info := result.(*Info)
+
+	if want := (DiffStat{Files: 1, Insertions: 2}); info.StagedDiff != want {
+		t.Errorf("expected StagedDiff %+v, got %+v", want, info.StagedDiff)
+	}
+	if want := (DiffStat{Files: 2, Insertions: 3, Deletions: 1}); info.UnstagedDiff != want {
+		t.Errorf("expected UnstagedDiff %+v, got %+v", want, info.UnstagedDiff)
+	}
+	if info.DiffFiles != 2 {
+		t.Errorf("expected 2 DiffFiles with new.txt counted once, got %d", info.DiffFiles)
+	}
+
+	// Off unless enabled
+	result, _ = (&Provider{workDir: dir}).Provide(context.Background())
+	if info := result.(*Info); info.StagedDiff != (DiffStat{}) || info.UnstagedDiff != (DiffStat{}) {
+		t.Errorf("expected no diff stats without the diff option, got %+v and %+v", info.StagedDiff, info.UnstagedDiff)
+	}
+}
+
+func TestProvider_UntrackedMode(t *testing.T) {
+	dir := setupUntrackedFiles(t)
+
+	tests := []struct {
+		mode UntrackedMode
+		want int
+	}{
+		{"", 3},              // notes.txt, src/new.go and build/
+		{UntrackedNormal, 3}, // Same as git's default
+		{UntrackedAll, 5},    // Every file in build/ too
+		{UntrackedNo, 0},
+	}
+
+	for _, tt := range tests {
+		for _, backend := range []Backend{BackendExec, BackendNative} {
+			p := &Provider{workDir: dir, backend: backend, untracked: tt.mode}
+			result, _ := p.Provide(context.Background())
+			if got := result.(*Info).Untracked; got != tt.want {
+				t.Errorf("%s backend with untracked %q: expected %d untracked, got %d", backend, tt.mode, tt.want, got)
+			}
+		}
+	}
+}
+
+func TestProvider_Timeout(t *testing.T) {
+	dir := setupGitRepo(t)
+	commitFile(t, dir, "README.md", "# Test")
+	createFile(t, dir, "README.md", "# Modified")
+	branch := strings.TrimSpace(runGit(t, dir, "branch", "--show-current"))
+
+	for _, backend := range []Backend{BackendExec, BackendNative} {
+		p := &Provider{workDir: dir, backend: backend, timeout: time.Nanosecond, diff: true}
+		result, _ := p.Provide(context.Background())
+		info := result.(*Info)
+
+		// The branch is still known; the counts aren't
+		if !info.IsRepo || info.Branch != branch {
+			t.Errorf("%s backend: expected repo on %q, got IsRepo %v, branch %q", backend, branch, info.IsRepo, info.Branch)
+		}
+		if !info.TimedOut.Has(QueryStatus) || !info.TimedOut.Has(QueryDiff) || !info.Partial() {
+			t.Errorf("%s backend: expected status and diff to time out, got %b", backend, info.TimedOut)
+		}
+	}
+
+	// With time to finish, nothing times out
+	for _, backend := range []Backend{BackendExec, BackendNative} {
+		p := &Provider{workDir: dir, backend: backend}
+		result, _ := p.Provide(context.Background())
+		if info := result.(*Info); info.TimedOut != 0 || info.Modified != 1 {
+			t.Errorf("%s backend: expected 1 modified without timeouts, got %d modified, timed out %b",
+				backend, info.Modified, info.TimedOut)
+		}
+	}
+}
+
+func TestProvider_TimeoutDetachedHead(t *testing.T) {
+	dir := setupGitRepo(t)
+	commitFile(t, dir, "README.md", "# Test")
+	hash := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	runGit(t, dir, "checkout", "--detach")
+
+	p := &Provider{workDir: dir, timeout: time.Nanosecond}
+	result, _ := p.Provide(context.Background())
+
+	if got, want := result.(*Info).Branch, "@"+hash[:shortHashLength]; got != want {
+		t.Errorf("expected branch %q, got %q", want, got)
+	}
+}
+
+func TestProvider_StatusConfigArgs(t *testing.T) {
+	dir := setupGitRepo(t)
+	commitFile(t, dir, "README.md", "# Test")
+	createFile(t, dir, "README.md", "# Modified")
+	createFile(t, dir, "new.txt", "new")
+
+	// Git without the builtin fsmonitor ignores it
+	p := &Provider{workDir: dir, backend: BackendExec, diff: true, untrackedCache: true, fsmonitor: true}
+	result, _ := p.Provide(context.Background())
+	info := result.(*Info)
+
+	if info.Modified != 1 || info.Untracked != 1 || info.UnstagedDiff.Files != 1 {
+		t.Errorf("expected 1 modified, 1 untracked and 1 file in diff, got %d, %d and %d",
+			info.Modified, info.Untracked, info.UnstagedDiff.Files)
+	}
+}
+
+func TestProvider_ChangeKinds(t *testing.T) {
+	dir := setupGitRepo(t)
+	commitFile(t, dir, "README.md", "# Test")
+	commitFile(t, dir, "old.txt", "old")
+	commitFile(t, dir, "gone.txt", "gone")
+
+	runGit(t, dir, "mv", "old.txt", "new.txt")
+	createFile(t, dir, "added.txt", "added")
+	runGit(t, dir, "add", "added.txt")
+	createFile(t, dir, "README.md", "# Modified")
+	if err := os.Remove(filepath.Join(dir, "gone.txt")); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+
+	result, _ := (&Provider{workDir: dir}).Provide(context.Background())
+	info := result.(*Info)
+
+	if want := (ChangeCounts{Added: 1, Renamed: 1}); info.IndexChanges != want {
+		t.Errorf("expected IndexChanges %+v, got %+v", want, info.IndexChanges)
+	}
+	if want := (ChangeCounts{Modified: 1, Deleted: 1}); info.WorktreeChanges != want {
+		t.Errorf("expected WorktreeChanges %+v, got %+v", want, info.WorktreeChanges)
+	}
+}
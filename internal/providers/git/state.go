@@ -0,0 +1,96 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// stateFiles are the files in the git directory that readState looks at, and that
+// change as an operation starts, advances or ends.
+var stateFiles = []string{
+	filepath.Join("rebase-merge", "msgnum"),
+	filepath.Join("rebase-apply", "next"),
+	"MERGE_HEAD",
+	"CHERRY_PICK_HEAD",
+	"REVERT_HEAD",
+	"BISECT_LOG",
+}
+
+// readState records the operation in progress in info, detected like git's prompt script
+// from the files git keeps in the (per-worktree) git directory while the operation runs.
+func (p *Provider) readState(info *Info) {
+	gitDir, _, ok := p.gitDirs()
+	if !ok {
+		return
+	}
+
+	// Rebases with the merge backend (the default, and interactive rebases)
+	if dir := filepath.Join(gitDir, "rebase-merge"); isDir(dir) {
+		info.State = StateRebase
+		info.StateStep = readInt(filepath.Join(dir, "msgnum"))
+		info.StateTotal = readInt(filepath.Join(dir, "end"))
+		info.RebaseBranch = readBranch(filepath.Join(dir, "head-name"))
+		return
+	}
+
+	// Rebases with the apply backend, and git am
+	if dir := filepath.Join(gitDir, "rebase-apply"); isDir(dir) {
+		info.State = StateAm
+		if isFile(filepath.Join(dir, "rebasing")) {
+			info.State = StateRebase
+			info.RebaseBranch = readBranch(filepath.Join(dir, "head-name"))
+		}
+		info.StateStep = readInt(filepath.Join(dir, "next"))
+		info.StateTotal = readInt(filepath.Join(dir, "last"))
+		return
+	}
+
+	switch {
+	case isFile(filepath.Join(gitDir, "MERGE_HEAD")):
+		info.State = StateMerge
+	case isFile(filepath.Join(gitDir, "CHERRY_PICK_HEAD")):
+		info.State = StateCherryPick
+	case isFile(filepath.Join(gitDir, "REVERT_HEAD")):
+		info.State = StateRevert
+	case isFile(filepath.Join(gitDir, "BISECT_LOG")):
+		info.State = StateBisect
+	}
+}
+
+// readInt returns the number stored in a file, or 0 if it's missing or invalid.
+func readInt(path string) int {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(string(content)))
+	return n
+}
+
+// readBranch returns the branch name stored as a ref in a file, e.g. "refs/heads/main" as "main".
+// Returns empty string if it's missing or "detached HEAD".
+func readBranch(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	branch, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "refs/heads/")
+	if !ok {
+		return ""
+	}
+	return branch
+}
+
+// isDir reports whether path is an existing directory.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// isFile reports whether path is an existing regular file.
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
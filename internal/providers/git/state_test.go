@@ -0,0 +1,118 @@
+package git
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+)
+
+// runGitMayFail executes a git command that is expected to stop, e.g. on a conflict.
+func runGitMayFail(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(cmd.Environ(), "GIT_EDITOR=true")
+	_ = cmd.Run()
+}
+
+// setupDivergedRepo creates a repository whose current branch "feature" has two commits
+// changing README.md, and whose "main" branch changed README.md too.
+func setupDivergedRepo(t *testing.T) string {
+	t.Helper()
+	dir := setupGitRepo(t)
+	runGit(t, dir, "checkout", "-q", "-b", "main")
+	commitFile(t, dir, "README.md", "# Initial")
+	runGit(t, dir, "checkout", "-q", "-b", "feature")
+	commitFile(t, dir, "README.md", "# Feature")
+	commitFile(t, dir, "other.txt", "other")
+	runGit(t, dir, "checkout", "-q", "main")
+	commitFile(t, dir, "README.md", "# Main")
+	runGit(t, dir, "checkout", "-q", "feature")
+	return dir
+}
+
+func TestProvider_State(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T) string
+		want  Info
+	}{
+		{
+			name: "none",
+			setup: func(t *testing.T) string {
+				dir := setupGitRepo(t)
+				commitFile(t, dir, "README.md", "# Test")
+				return dir
+			},
+			want: Info{},
+		},
+		{
+			name: "rebase",
+			setup: func(t *testing.T) string {
+				dir := setupDivergedRepo(t)
+				runGitMayFail(t, dir, "rebase", "--merge", "main")
+				return dir
+			},
+			want: Info{State: StateRebase, StateStep: 1, StateTotal: 2, RebaseBranch: "feature"},
+		},
+		{
+			name: "rebase with apply backend",
+			setup: func(t *testing.T) string {
+				dir := setupDivergedRepo(t)
+				runGitMayFail(t, dir, "rebase", "--apply", "main")
+				return dir
+			},
+			want: Info{State: StateRebase, StateStep: 1, StateTotal: 2, RebaseBranch: "feature"},
+		},
+		{
+			name: "merge",
+			setup: func(t *testing.T) string {
+				dir := setupDivergedRepo(t)
+				runGitMayFail(t, dir, "merge", "main")
+				return dir
+			},
+			want: Info{State: StateMerge},
+		},
+		{
+			name: "cherry-pick",
+			setup: func(t *testing.T) string {
+				dir := setupDivergedRepo(t)
+				runGitMayFail(t, dir, "cherry-pick", "main")
+				return dir
+			},
+			want: Info{State: StateCherryPick},
+		},
+		{
+			name: "revert",
+			setup: func(t *testing.T) string {
+				dir := setupDivergedRepo(t)
+				runGitMayFail(t, dir, "revert", "main")
+				return dir
+			},
+			want: Info{State: StateRevert},
+		},
+		{
+			name: "bisect",
+			setup: func(t *testing.T) string {
+				dir := setupDivergedRepo(t)
+				runGit(t, dir, "bisect", "start")
+				return dir
+			},
+			want: Info{State: StateBisect},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := tt.setup(t)
+
+			result, _ := (&Provider{workDir: dir}).Provide(context.Background())
+			info := result.(*Info)
+
+			got := Info{State: info.State, StateStep: info.StateStep, StateTotal: info.StateTotal, RebaseBranch: info.RebaseBranch}
+			if got != tt.want {
+				t.Errorf("state = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Length of abbreviated hashes where git's own abbreviation isn't available,
+	// e.g. in the native backend.
+	shortHashLength = 7
+
+	// commitFormat is the `git log` format parsed by parseCommit:
+	// abbreviated hash, author name, committer time and subject, NUL separated.
+	commitFormat = "%h%x00%an%x00%ct%x00%s"
+	commitFields = 4
+
+	// Tab separated fields of a `git diff --numstat` line: added, deleted and path.
+	numstatFields = 3
+)
+
+// parseStatus builds Info from `git status --porcelain=v2 --branch --show-stash` output.
+//
+// Headers start with "# ", entries with a type character:
+//
+//	# branch.oid <commit> | (initial)
+//	# branch.head <branch> | (detached)
+//	# branch.upstream <upstream>
+//	# branch.ab +<ahead> -<behind>   (only if the upstream exists)
+//	# stash <count>                  (only if there are stash entries)
+//	1 <XY> <sub> ...                 changed entry; sub is "N..." or "S<c><m><u>" for submodules
+//	2 <XY> ...                       renamed or copied entry
+//	u <XY> ...                       unmerged entry
+//	? <path>                         untracked file
+func parseStatus(output string) *Info {
+	info := &Info{IsRepo: true}
+	var oid, head string
+
+	for line := range strings.SplitSeq(strings.TrimSuffix(output, "\n"), "\n") {
+		if header, ok := strings.CutPrefix(line, "# "); ok {
+			key, value, _ := strings.Cut(header, " ")
+			switch key {
+			case "branch.oid":
+				oid = value
+			case "branch.head":
+				head = value
+			case "branch.ab":
+				info.Ahead, info.Behind = parseAheadBehind(value)
+				info.HasUpstream = true
+			case "stash":
+				info.Stash, _ = strconv.Atoi(value)
+			}
+			continue
+		}
+
+		if line == "" {
+			continue
+		}
+
+		switch line[0] {
+		case '?':
+			info.Untracked++
+		case 'u':
+			info.Conflicts++
+		case '1', '2':
+			if len(line) < 4 { //nolint:mnd // type, space and XY
+				continue
+			}
+			// XY: X = staged status, Y = unstaged status, '.' = unchanged
+			if line[2] != '.' {
+				info.Staged++
+				info.IndexChanges.count(line[2])
+			}
+			if line[3] != '.' {
+				info.Modified++
+				info.WorktreeChanges.count(line[3])
+			}
+			if len(line) >= 9 && line[5] == 'S' { //nolint:mnd // "1 XY S<c><m><u>"
+				// Submodule: c = commit changed, m = tracked changes, u = untracked files
+				if line[6] == 'C' {
+					info.SubmodulesOutOfDate++
+				}
+				if line[7] == 'M' || line[8] == 'U' {
+					info.SubmodulesDirty++
+				}
+			}
+		}
+	}
+
+	info.Branch = head
+	if head == "(detached)" {
+		info.Branch = "@" + oid[:min(len(oid), shortHashLength)]
+	}
+
+	return info
+}
+
+// parseAheadBehind parses the "+<ahead> -<behind>" value of the branch.ab header.
+//
+//nolint:nonamedreturns // named returns document the meaning of each value
+func parseAheadBehind(value string) (ahead, behind int) {
+	a, b, _ := strings.Cut(value, " ")
+	ahead, _ = strconv.Atoi(strings.TrimPrefix(a, "+"))
+	behind, _ = strconv.Atoi(strings.TrimPrefix(b, "-"))
+	return ahead, behind
+}
+
+// parseCommit records the HEAD commit from `git log -1 --format=<commitFormat>` output in info.
+// Leaves info unchanged if output is empty, e.g. before the first commit.
+func parseCommit(info *Info, output string) {
+	fields := strings.SplitN(strings.TrimSuffix(output, "\n"), "\x00", commitFields)
+	if len(fields) != commitFields {
+		return
+	}
+
+	info.CommitHash = fields[0]
+	info.CommitAuthor = fields[1]
+	if seconds, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+		info.CommitTime = time.Unix(seconds, 0)
+	}
+	info.CommitSubject = fields[3]
+}
+
+// parseNumstat parses `git diff --numstat -z` output into the diff's stats and the
+// changed paths. Each file is "<added>\t<deleted>\t<path>\x00", or for a rename
+// "<added>\t<deleted>\t\x00<old path>\x00<new path>\x00"; binary files show "-" counts.
+func parseNumstat(output string) (DiffStat, []string) {
+	var stat DiffStat
+	var paths []string
+
+	fields := strings.Split(output, "\x00")
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(fields[i], "\t", numstatFields)
+		if len(parts) != numstatFields {
+			continue
+		}
+
+		path := parts[2]
+		if path == "" {
+			// Renamed: the old and new path follow
+			if i+2 >= len(fields) {
+				break
+			}
+			path = fields[i+2]
+			i += 2
+		}
+
+		stat.Files++
+		if added, err := strconv.Atoi(parts[0]); err == nil {
+			stat.Insertions += added
+		}
+		if deleted, err := strconv.Atoi(parts[1]); err == nil {
+			stat.Deletions += deleted
+		}
+		paths = append(paths, path)
+	}
+	return stat, paths
+}
+
+// countDistinct returns the number of distinct paths in the lists.
+func countDistinct(lists ...[]string) int {
+	seen := map[string]bool{}
+	for _, list := range lists {
+		for _, path := range list {
+			seen[path] = true
+		}
+	}
+	return len(seen)
+}
@@ -0,0 +1,163 @@
+package git
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   Info
+	}{
+		{
+			name: "clean branch without upstream",
+			output: "# branch.oid 1234567890abcdef1234567890abcdef12345678\n" +
+				"# branch.head main\n",
+			want: Info{IsRepo: true, Branch: "main"},
+		},
+		{
+			name: "unborn branch",
+			output: "# branch.oid (initial)\n" +
+				"# branch.head main\n" +
+				"? new.txt\n",
+			want: Info{IsRepo: true, Branch: "main", Untracked: 1},
+		},
+		{
+			name: "detached head",
+			output: "# branch.oid 1234567890abcdef1234567890abcdef12345678\n" +
+				"# branch.head (detached)\n",
+			want: Info{IsRepo: true, Branch: "@1234567"},
+		},
+		{
+			name: "upstream with ahead, behind and stash",
+			output: "# branch.oid 1234567890abcdef1234567890abcdef12345678\n" +
+				"# branch.head feature\n" +
+				"# branch.upstream origin/feature\n" +
+				"# branch.ab +3 -2\n" +
+				"# stash 4\n",
+			want: Info{IsRepo: true, Branch: "feature", HasUpstream: true, Ahead: 3, Behind: 2, Stash: 4},
+		},
+		{
+			name: "upstream gone",
+			output: "# branch.oid 1234567890abcdef1234567890abcdef12345678\n" +
+				"# branch.head feature\n" +
+				"# branch.upstream origin/feature\n",
+			want: Info{IsRepo: true, Branch: "feature"},
+		},
+		{
+			name: "entries",
+			output: "# branch.oid 1234567890abcdef1234567890abcdef12345678\n" +
+				"# branch.head main\n" +
+				"1 M. N... 100644 100644 100644 abc abc staged.txt\n" +
+				"1 .M N... 100644 100644 100644 abc abc modified.txt\n" +
+				"1 MM N... 100644 100644 100644 abc abc both.txt\n" +
+				"2 R. N... 100644 100644 100644 abc abc R100 new.txt\told.txt\n" +
+				"u UU N... 100644 100644 100644 100644 abc abc abc conflict.txt\n" +
+				"? untracked.txt\n" +
+				"? other file.txt\n",
+			want: Info{
+				IsRepo: true, Branch: "main", Staged: 3, Modified: 2, Untracked: 2, Conflicts: 1,
+				IndexChanges:    ChangeCounts{Modified: 2, Renamed: 1},
+				WorktreeChanges: ChangeCounts{Modified: 2},
+			},
+		},
+		{
+			name: "change kinds",
+			output: "# branch.oid 1234567890abcdef1234567890abcdef12345678\n" +
+				"# branch.head main\n" +
+				"1 A. N... 000000 100644 100644 000 abc added.txt\n" +
+				"1 AM N... 000000 100644 100644 000 abc added-then-modified.txt\n" +
+				"1 D. N... 100644 000000 000000 abc 000 deleted.txt\n" +
+				"1 .D N... 100644 100644 000000 abc abc removed.txt\n" +
+				"1 T. N... 100644 120000 120000 abc def link.txt\n" +
+				"1 .T N... 100644 100644 120000 abc abc other-link.txt\n" +
+				"2 C. N... 100644 100644 100644 abc abc C75 copy.txt\toriginal.txt\n" +
+				"1 .A N... 000000 000000 100644 000 000 intent.txt\n",
+			want: Info{
+				IsRepo: true, Branch: "main", Staged: 5, Modified: 4,
+				IndexChanges:    ChangeCounts{Added: 2, Deleted: 1, Renamed: 1, TypeChanged: 1},
+				WorktreeChanges: ChangeCounts{Added: 1, Modified: 1, Deleted: 1, TypeChanged: 1},
+			},
+		},
+		{
+			name: "submodules",
+			output: "# branch.oid 1234567890abcdef1234567890abcdef12345678\n" +
+				"# branch.head main\n" +
+				"1 .M SC.. 160000 160000 160000 abc abc lib\n" +
+				"1 .M S.MU 160000 160000 160000 abc abc vendor/tool\n" +
+				"1 .M S..U 160000 160000 160000 abc abc vendor/other\n",
+			want: Info{
+				IsRepo: true, Branch: "main", Modified: 3, SubmodulesOutOfDate: 1, SubmodulesDirty: 2,
+				WorktreeChanges: ChangeCounts{Modified: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseStatus(tt.output); !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("parseStatus() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommit(t *testing.T) {
+	info := &Info{}
+	parseCommit(info, "abc1234\x00Test User\x001700000000\x00Fix: handle tabs\tin subjects\n")
+
+	want := Info{
+		CommitHash:    "abc1234",
+		CommitAuthor:  "Test User",
+		CommitTime:    time.Unix(1700000000, 0),
+		CommitSubject: "Fix: handle tabs\tin subjects",
+	}
+	if *info != want {
+		t.Errorf("parseCommit() = %+v, want %+v", *info, want)
+	}
+
+	// No output before the first commit
+	info = &Info{}
+	parseCommit(info, "")
+	if *info != (Info{}) {
+		t.Errorf("expected no commit info for empty output, got %+v", *info)
+	}
+}
+
+func TestParseNumstat(t *testing.T) {
+	tests := []struct {
+		output    string
+		want      DiffStat
+		wantPaths []string
+	}{
+		{"", DiffStat{}, nil},
+		{"1\t0\tREADME.md\x00", DiffStat{Files: 1, Insertions: 1}, []string{"README.md"}},
+		{
+			"10\t2\tsrc/main.go\x00-\t-\timage.bin\x00",
+			DiffStat{Files: 2, Insertions: 10, Deletions: 2},
+			[]string{"src/main.go", "image.bin"},
+		},
+		{
+			"0\t3\t\x00old name.txt\x00new name.txt\x00",
+			DiffStat{Files: 1, Deletions: 3},
+			[]string{"new name.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		got, paths := parseNumstat(tt.output)
+		if got != tt.want || !slices.Equal(paths, tt.wantPaths) {
+			t.Errorf("parseNumstat(%q) = %+v, %q, want %+v, %q", tt.output, got, paths, tt.want, tt.wantPaths)
+		}
+	}
+}
+
+func TestCountDistinct(t *testing.T) {
+	if got := countDistinct([]string{"a", "b"}, []string{"b", "c"}, nil); got != 3 {
+		t.Errorf("countDistinct() = %d, want 3", got)
+	}
+}
@@ -0,0 +1,147 @@
+package git
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// describeCandidates is how many tagged commits are considered, like git describe's --candidates.
+const describeCandidates = 10
+
+// parseDescribe records the nearest tag from `git describe --tags --long` output in info,
+// e.g. "v1.2.0-3-gabc1234". Tag names may contain dashes, so the output is split from the right.
+// Leaves info unchanged if output is empty, e.g. without tags.
+func parseDescribe(info *Info, output string) {
+	rest, _, found := cutLast(strings.TrimSpace(output), "-g")
+	if !found {
+		return
+	}
+	tag, count, found := cutLast(rest, "-")
+	if !found {
+		return
+	}
+	distance, err := strconv.Atoi(count)
+	if err != nil {
+		return
+	}
+	info.Tag = tag
+	info.TagDistance = distance
+	info.OnTag = distance == 0
+}
+
+// cutLast slices s around the last instance of sep.
+//
+//nolint:nonamedreturns // named returns tell the two parts apart
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// nativeDescribe records the nearest tag reachable from head in info, like `git describe --tags`.
+// The nearest of the first describeCandidates tagged commits found walking history newest
+// first wins; ties go to the one found first. Annotated tags win over lightweight tags on the
+// same commit, then names sort. Leaves info unchanged without tags or if ctx is done first.
+func nativeDescribe(ctx context.Context, repo *gogit.Repository, head plumbing.Hash, info *Info) {
+	tags, err := tagsByCommit(repo)
+	if err != nil || len(tags) == 0 {
+		return
+	}
+
+	// Collect candidates walking history newest first
+	var candidates []plumbing.Hash
+	seen := map[plumbing.Hash]bool{head: true}
+	queue := &commitQueue{}
+	if commit, commitErr := repo.CommitObject(head); commitErr == nil {
+		heap.Push(queue, commit)
+	}
+	for queue.Len() > 0 && len(candidates) < describeCandidates {
+		if ctx.Err() != nil {
+			return
+		}
+		commit, _ := heap.Pop(queue).(*object.Commit)
+		if _, tagged := tags[commit.Hash]; tagged {
+			candidates = append(candidates, commit.Hash)
+		}
+		for _, parent := range commit.ParentHashes {
+			if seen[parent] {
+				continue
+			}
+			seen[parent] = true
+			parentCommit, parentErr := repo.CommitObject(parent)
+			if errors.Is(parentErr, plumbing.ErrObjectNotFound) {
+				continue // Shallow clone boundary
+			}
+			if parentErr != nil {
+				return
+			}
+			heap.Push(queue, parentCommit)
+		}
+	}
+
+	// The distance is the number of commits reachable from head but not the tag
+	best, bestDistance := plumbing.ZeroHash, -1
+	for _, candidate := range candidates {
+		distance, _, divergenceErr := countDivergence(ctx, repo, head, candidate)
+		if divergenceErr != nil {
+			return
+		}
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if bestDistance < 0 {
+		return
+	}
+
+	info.Tag = tags[best]
+	info.TagDistance = bestDistance
+	info.OnTag = bestDistance == 0
+}
+
+// tagsByCommit returns the preferred tag name of each tagged commit.
+func tagsByCommit(repo *gogit.Repository) (map[plumbing.Hash]string, error) {
+	refs, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[plumbing.Hash]string{}
+	annotated := map[plumbing.Hash]bool{}
+	add := func(hash plumbing.Hash, name string, isAnnotated bool) {
+		if current, found := names[hash]; found {
+			if annotated[hash] && !isAnnotated {
+				return
+			}
+			if annotated[hash] == isAnnotated && current < name {
+				return
+			}
+		}
+		names[hash] = name
+		annotated[hash] = isAnnotated
+	}
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		// Annotated tags point to a tag object, which points to the commit
+		if tagObject, tagErr := repo.TagObject(ref.Hash()); tagErr == nil {
+			if commit, commitErr := tagObject.Commit(); commitErr == nil {
+				add(commit.Hash, ref.Name().Short(), true)
+			}
+			return nil
+		}
+		add(ref.Hash(), ref.Name().Short(), false)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
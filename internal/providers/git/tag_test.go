@@ -0,0 +1,55 @@
+package git
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseDescribe(t *testing.T) {
+	tests := []struct {
+		output string
+		want   Info
+	}{
+		{"", Info{}},
+		{"v1.2.0-0-gabc1234\n", Info{Tag: "v1.2.0", OnTag: true}},
+		{"v1.2.0-3-gabc1234\n", Info{Tag: "v1.2.0", TagDistance: 3}},
+		{"release-2024-01-12-gabc1234\n", Info{Tag: "release-2024-01", TagDistance: 12}},
+		{"fatal: No names found, cannot describe anything.\n", Info{}},
+	}
+
+	for _, tt := range tests {
+		info := &Info{}
+		parseDescribe(info, tt.output)
+		if *info != tt.want {
+			t.Errorf("parseDescribe(%q) = %+v, want %+v", tt.output, *info, tt.want)
+		}
+	}
+}
+
+func TestProvider_Tag(t *testing.T) {
+	dir := setupGitRepo(t)
+	commitFile(t, dir, "README.md", "# Test")
+	runGit(t, dir, "tag", "-a", "v1.0.0", "-m", "release 1.0.0")
+
+	// Tags are opt-in
+	result, _ := (&Provider{workDir: dir}).Provide(context.Background())
+	if info := result.(*Info); info.Tag != "" {
+		t.Errorf("expected no Tag without the tags option, got %q", info.Tag)
+	}
+
+	p := &Provider{workDir: dir, tags: true}
+	result, _ = p.Provide(context.Background())
+	info := result.(*Info)
+	if info.Tag != "v1.0.0" || !info.OnTag || info.TagDistance != 0 {
+		t.Errorf("expected HEAD on v1.0.0, got tag=%q onTag=%v distance=%d", info.Tag, info.OnTag, info.TagDistance)
+	}
+
+	commitFile(t, dir, "a.txt", "a")
+	commitFile(t, dir, "b.txt", "b")
+
+	result, _ = p.Provide(context.Background())
+	info = result.(*Info)
+	if info.Tag != "v1.0.0" || info.OnTag || info.TagDistance != 2 {
+		t.Errorf("expected 2 commits since v1.0.0, got tag=%q onTag=%v distance=%d", info.Tag, info.OnTag, info.TagDistance)
+	}
+}
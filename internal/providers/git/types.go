@@ -1,5 +1,7 @@
 package git
 
+import "time"
+
 // Info represents git repository information.
 type Info struct {
 	// Branch is the current branch name, or "@<hash>" for detached HEAD
@@ -14,6 +16,12 @@ type Info struct {
 	// Modified is the number of modified (unstaged) files
 	Modified int
 
+	// IndexChanges breaks Staged down by kind of change
+	IndexChanges ChangeCounts
+
+	// WorktreeChanges breaks Modified down by kind of change
+	WorktreeChanges ChangeCounts
+
 	// Untracked is the number of untracked files
 	Untracked int
 
@@ -31,4 +39,184 @@ type Info struct {
 
 	// Stash is the number of stash entries
 	Stash int
+
+	// State is the operation in progress (one of the State constants), empty if none
+	State string
+
+	// StateStep is the current step of a rebase or am (0 if not applicable)
+	StateStep int
+
+	// StateTotal is the number of steps of a rebase or am (0 if not applicable)
+	StateTotal int
+
+	// RebaseBranch is the branch being rebased (empty if not rebasing or rebasing a detached HEAD)
+	RebaseBranch string
+
+	// Worktree is the name of a linked worktree, or the directory name of the main worktree
+	Worktree string
+
+	// WorktreePath is the root directory of the working tree
+	WorktreePath string
+
+	// IsMainWorktree indicates the main working tree rather than one added with `git worktree add`
+	IsMainWorktree bool
+
+	// Submodules is the number of submodules declared in .gitmodules
+	Submodules int
+
+	// SubmodulesDirty is the number of submodules with modified or untracked content
+	SubmodulesDirty int
+
+	// SubmodulesOutOfDate is the number of submodules whose checked out commit differs from the recorded one
+	SubmodulesOutOfDate int
+
+	// CommitHash is the abbreviated hash of the HEAD commit (empty before the first commit)
+	CommitHash string
+
+	// CommitSubject is the first line of the HEAD commit message
+	CommitSubject string
+
+	// CommitAuthor is the author name of the HEAD commit
+	CommitAuthor string
+
+	// CommitTime is the committer time of the HEAD commit
+	CommitTime time.Time
+
+	// StagedDiff summarizes the changes staged in the index, like `git diff --cached --shortstat`
+	StagedDiff DiffStat
+
+	// UnstagedDiff summarizes the unstaged working tree changes, like `git diff --shortstat`
+	UnstagedDiff DiffStat
+
+	// DiffFiles is the number of files with staged or unstaged changes;
+	// a file with both counts once, unlike in StagedDiff.Files + UnstagedDiff.Files
+	DiffFiles int
+
+	// Tag is the nearest tag reachable from HEAD, like `git describe --tags`.
+	// Only read when the provider's tags option is enabled.
+	Tag string
+
+	// TagDistance is the number of commits since Tag
+	TagDistance int
+
+	// OnTag is true when HEAD is exactly on Tag
+	OnTag bool
+
+	// RemoteURL is the URL of the provider's remote, with insteadOf rules applied and credentials removed
+	RemoteURL string
+
+	// RemoteHost is the host name in RemoteURL (empty for local paths)
+	RemoteHost string
+
+	// RemoteOwner is the user, organization or group path owning the remote repository
+	RemoteOwner string
+
+	// RemoteRepo is the remote repository name, without ".git"
+	RemoteRepo string
+
+	// Forge is the service hosting the remote (one of the Forge constants), empty without a host
+	Forge string
+
+	// TimedOut is the set of queries stopped by the provider's timeout; their fields are unknown
+	// rather than zero. If the status query timed out, only IsRepo and Branch are known.
+	TimedOut Query
 }
+
+// Query identifies the part of Info read by one query; combine them for a set of queries.
+type Query uint
+
+// Queries of the provider. The exec backend reads several parts with one git status call.
+const (
+	// QueryStatus reads the file counts (Staged, Modified, ..., and their breakdowns).
+	QueryStatus Query = 1 << iota
+	// QueryUpstream reads Ahead, Behind and HasUpstream.
+	QueryUpstream
+	// QueryStash reads Stash.
+	QueryStash
+	// QuerySubmodules reads SubmodulesDirty and SubmodulesOutOfDate.
+	QuerySubmodules
+	// QueryCommit reads the Commit fields.
+	QueryCommit
+	// QueryDiff reads StagedDiff and UnstagedDiff.
+	QueryDiff
+	// QueryTag reads Tag, TagDistance and OnTag.
+	QueryTag
+	// QueryRemote reads the Remote fields and Forge.
+	QueryRemote
+)
+
+// Partial reports whether a query timed out; the cache keeps only complete results.
+func (info *Info) Partial() bool {
+	return info.TimedOut != 0
+}
+
+// Has reports whether the set q includes any of queries.
+func (q Query) Has(queries Query) bool {
+	return q&queries != 0
+}
+
+// ChangeCounts counts changed files by kind of change.
+type ChangeCounts struct {
+	// Added is the number of added files
+	Added int
+
+	// Modified is the number of files with modified content
+	Modified int
+
+	// Deleted is the number of deleted files
+	Deleted int
+
+	// Renamed is the number of renamed or copied files
+	Renamed int
+
+	// TypeChanged is the number of files whose type changed, e.g. from a file to a symlink
+	TypeChanged int
+}
+
+// count records a change by its status letter, as in `git status --porcelain`.
+// Other letters, e.g. '.' or ' ' for no change, are ignored.
+func (c *ChangeCounts) count(code byte) {
+	switch code {
+	case 'A':
+		c.Added++
+	case 'M':
+		c.Modified++
+	case 'D':
+		c.Deleted++
+	case 'R', 'C':
+		c.Renamed++
+	case 'T':
+		c.TypeChanged++
+	}
+}
+
+// DiffStat summarizes a diff. Untracked files aren't part of it.
+type DiffStat struct {
+	// Files is the number of changed files
+	Files int
+
+	// Insertions is the number of added lines
+	Insertions int
+
+	// Deletions is the number of removed lines
+	Deletions int
+}
+
+// Operations that can be in progress in a repository.
+const (
+	StateRebase     = "rebase"
+	StateAm         = "am"
+	StateMerge      = "merge"
+	StateCherryPick = "cherry-pick"
+	StateRevert     = "revert"
+	StateBisect     = "bisect"
+)
+
+// Services hosting remote repositories.
+const (
+	ForgeGitHub     = "github"
+	ForgeGitLab     = "gitlab"
+	ForgeBitbucket  = "bitbucket"
+	ForgeGitea      = "gitea" // Including Forgejo and Codeberg
+	ForgeSelfHosted = "self-hosted"
+)
@@ -0,0 +1,33 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5/config"
+)
+
+// readWorktree records which working tree the provider looks at, and how many submodules it has.
+func (p *Provider) readWorktree(info *Info) {
+	gitDir, commonDir, ok := p.gitDirs()
+	if !ok {
+		return
+	}
+
+	// Linked worktrees have their own git directory in <common>/worktrees/<name>
+	info.WorktreePath = p.CacheWorkspace()
+	info.IsMainWorktree = gitDir == commonDir
+	info.Worktree = filepath.Base(info.WorktreePath)
+	if !info.IsMainWorktree {
+		info.Worktree = filepath.Base(gitDir)
+	}
+
+	content, err := os.ReadFile(filepath.Join(info.WorktreePath, ".gitmodules"))
+	if err != nil {
+		return
+	}
+	modules := config.NewModules()
+	if modules.Unmarshal(content) == nil {
+		info.Submodules = len(modules.Submodules)
+	}
+}
@@ -0,0 +1,92 @@
+package git
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+// setupSubmoduleRepo creates a repository with two submodules, "lib" and "vendor/tool".
+func setupSubmoduleRepo(t *testing.T) string {
+	t.Helper()
+
+	sub := setupGitRepo(t)
+	commitFile(t, sub, "lib.txt", "v1")
+	commitFile(t, sub, "lib.txt", "v2")
+
+	dir := setupGitRepo(t)
+	commitFile(t, dir, "README.md", "# Test")
+	runGit(t, dir, "-c", "protocol.file.allow=always", "submodule", "add", "-q", sub, "lib")
+	runGit(t, dir, "-c", "protocol.file.allow=always", "submodule", "add", "-q", sub, "vendor/tool")
+	runGit(t, dir, "commit", "-q", "-m", "add submodules")
+	return dir
+}
+
+func TestProvider_MainWorktree(t *testing.T) {
+	dir := setupGitRepo(t)
+	commitFile(t, dir, "README.md", "# Test")
+
+	result, _ := (&Provider{workDir: dir}).Provide(context.Background())
+	info := result.(*Info)
+
+	if !info.IsMainWorktree {
+		t.Error("expected IsMainWorktree to be true")
+	}
+	if info.WorktreePath != dir {
+		t.Errorf("expected WorktreePath %q, got %q", dir, info.WorktreePath)
+	}
+	if info.Worktree != filepath.Base(dir) {
+		t.Errorf("expected Worktree %q, got %q", filepath.Base(dir), info.Worktree)
+	}
+}
+
+func TestProvider_LinkedWorktree(t *testing.T) {
+	dir := setupGitRepo(t)
+	commitFile(t, dir, "README.md", "# Test")
+
+	linked := filepath.Join(t.TempDir(), "parallel")
+	runGit(t, dir, "worktree", "add", "-q", "-b", "parallel-work", linked)
+
+	result, _ := (&Provider{workDir: linked}).Provide(context.Background())
+	info := result.(*Info)
+
+	if info.IsMainWorktree {
+		t.Error("expected IsMainWorktree to be false in a linked worktree")
+	}
+	if info.Worktree != "parallel" {
+		t.Errorf("expected Worktree 'parallel', got %q", info.Worktree)
+	}
+	if info.WorktreePath != linked {
+		t.Errorf("expected WorktreePath %q, got %q", linked, info.WorktreePath)
+	}
+	if info.Branch != "parallel-work" {
+		t.Errorf("expected branch 'parallel-work', got %q", info.Branch)
+	}
+}
+
+func TestProvider_Submodules(t *testing.T) {
+	dir := setupSubmoduleRepo(t)
+
+	p := &Provider{workDir: dir}
+	result, _ := p.Provide(context.Background())
+	info := result.(*Info)
+
+	if info.Submodules != 2 || info.SubmodulesDirty != 0 || info.SubmodulesOutOfDate != 0 {
+		t.Errorf("expected 2 clean submodules, got count=%d dirty=%d outOfDate=%d",
+			info.Submodules, info.SubmodulesDirty, info.SubmodulesOutOfDate)
+	}
+
+	// Untracked content in one submodule, another commit checked out in the other
+	createFile(t, filepath.Join(dir, "lib"), "scratch.txt", "scratch")
+	runGit(t, filepath.Join(dir, "vendor", "tool"), "checkout", "-q", "HEAD~1")
+
+	result, _ = p.Provide(context.Background())
+	info = result.(*Info)
+
+	if info.SubmodulesDirty != 1 {
+		t.Errorf("expected 1 dirty submodule, got %d", info.SubmodulesDirty)
+	}
+	if info.SubmodulesOutOfDate != 1 {
+		t.Errorf("expected 1 out-of-date submodule, got %d", info.SubmodulesOutOfDate)
+	}
+}
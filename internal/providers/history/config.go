@@ -0,0 +1,38 @@
+package history
+
+import (
+	"time"
+
+	"github.com/mirage20/ccstatus-go/internal/core"
+)
+
+const (
+	// Default number of samples kept per session.
+	defaultSamples = 20
+
+	// How long history survives without new invocations.
+	defaultRetention = 24 * time.Hour
+)
+
+// Config defines configuration for the history provider.
+type Config struct {
+	// Cache configuration (history manages its own entries, so TTL stays 0)
+	Cache core.CacheConfig `yaml:"cache"`
+
+	// Number of samples to keep (oldest are dropped first)
+	Samples int `yaml:"samples"`
+
+	// How long stored history is kept after the last sample
+	Retention time.Duration `yaml:"retention"`
+}
+
+// defaultConfig returns the default configuration for history provider.
+func defaultConfig() *Config {
+	return &Config{
+		Cache: core.CacheConfig{
+			TTL: 0, // Never wrap - history reads and writes the cache itself
+		},
+		Samples:   defaultSamples,
+		Retention: defaultRetention,
+	}
+}
@@ -0,0 +1,11 @@
+package history
+
+import "github.com/mirage20/ccstatus-go/internal/core"
+
+// Key is the unique identifier for the history provider.
+const Key = core.ProviderKey("history")
+
+// GetHistory is a typed getter for components to use.
+func GetHistory(ctx *core.RenderContext) (*History, bool) {
+	return core.Get[*History](ctx, Key)
+}
@@ -0,0 +1,120 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	"github.com/mirage20/ccstatus-go/internal/config"
+	"github.com/mirage20/ccstatus-go/internal/core"
+)
+
+// Schema version of History in the cache; bump when History or Sample changes.
+const schemaVersion = 1
+
+func init() {
+	// Self-register with type factory
+	core.RegisterProvider(string(Key), New, func() any {
+		return &History{}
+	}, schemaVersion)
+}
+
+// Provider records session usage samples in the cache and returns the history.
+type Provider struct {
+	session   *core.ClaudeSession
+	cache     core.Cache
+	samples   int
+	retention time.Duration
+}
+
+// New creates a new history provider with config.
+func New(cfgReader *config.Reader, session *core.ClaudeSession) (core.Provider, core.CacheConfig) {
+	cfg := config.GetProvider(cfgReader, "history", defaultConfig())
+
+	return &Provider{
+		session:   session,
+		samples:   cfg.Samples,
+		retention: cfg.Retention,
+	}, cfg.Cache
+}
+
+// Key returns the unique identifier for this provider.
+func (p *Provider) Key() core.ProviderKey {
+	return Key
+}
+
+// UseCache stores the session cache used to persist samples.
+func (p *Provider) UseCache(cache core.Cache) {
+	p.cache = cache
+}
+
+// Provide appends the current usage to the stored history and returns it.
+func (p *Provider) Provide(_ context.Context) (any, error) {
+	h := &History{}
+	if p.cache != nil {
+		// Ignore cache errors - a broken entry just restarts the history
+		if found, err := p.cache.Get(string(Key), h); !found || err != nil {
+			h = &History{}
+		}
+	}
+
+	sample := p.currentSample()
+
+	// Only record when usage changed, so refreshes within a turn don't flatten the trend
+	if n := len(h.Samples); n > 0 && sameUsage(h.Samples[n-1], sample) {
+		return h, nil
+	}
+
+	h.Samples = append(h.Samples, sample)
+	if p.samples > 0 && len(h.Samples) > p.samples {
+		h.Samples = h.Samples[len(h.Samples)-p.samples:]
+	}
+
+	if p.cache != nil {
+		_ = p.cache.Set(string(Key), h, p.retention)
+	}
+
+	return h, nil
+}
+
+// currentSample builds a sample from the Claude session.
+func (p *Provider) currentSample() Sample {
+	cw := p.session.ContextWindow
+	sample := Sample{
+		At:                time.Now(),
+		ContextWindowSize: cw.ContextWindowSize,
+	}
+
+	if usage := cw.CurrentUsage; usage != nil {
+		sample.ContextTokens = usage.InputTokens + usage.OutputTokens +
+			usage.CacheCreationInputTokens + usage.CacheReadInputTokens
+	}
+
+	if rl := p.session.RateLimits; rl != nil {
+		if rl.FiveHour != nil {
+			v := rl.FiveHour.UsedPercentage
+			sample.FiveHour = &v
+		}
+		if rl.SevenDay != nil {
+			v := rl.SevenDay.UsedPercentage
+			sample.SevenDay = &v
+		}
+	}
+
+	return sample
+}
+
+// sameUsage reports whether two samples carry identical usage values.
+func sameUsage(a, b Sample) bool {
+	return a.ContextTokens == b.ContextTokens &&
+		a.ContextWindowSize == b.ContextWindowSize &&
+		equalPtr(a.FiveHour, b.FiveHour) &&
+		equalPtr(a.SevenDay, b.SevenDay)
+}
+
+// equalPtr compares two optional values.
+func equalPtr(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,106 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirage20/ccstatus-go/internal/cache/file"
+	"github.com/mirage20/ccstatus-go/internal/core"
+)
+
+// newSession creates a session with the given context tokens and 5-hour utilization.
+func newSession(tokens int64, fiveHour float64) *core.ClaudeSession {
+	return &core.ClaudeSession{
+		SessionID: "test-session",
+		ContextWindow: core.ContextWindow{
+			ContextWindowSize: 200000,
+			CurrentUsage:      &core.ContextUsage{InputTokens: tokens},
+		},
+		RateLimits: &core.SessionRateLimits{
+			FiveHour: &core.SessionRateLimit{UsedPercentage: fiveHour},
+		},
+	}
+}
+
+// provide runs one invocation against a fresh cache instance, like a separate ccstatus process.
+func provide(t *testing.T, dir string, session *core.ClaudeSession, samples int) *History {
+	t.Helper()
+	c := file.NewCache(dir, session.SessionID)
+	p := &Provider{session: session, samples: samples, retention: time.Hour}
+	p.UseCache(c)
+
+	result, err := p.Provide(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = c.Close(); err != nil {
+		t.Fatalf("failed to close cache: %v", err)
+	}
+	return result.(*History)
+}
+
+func TestProvider_AccumulatesAcrossInvocations(t *testing.T) {
+	dir := t.TempDir()
+
+	provide(t, dir, newSession(20000, 10), 10)
+	provide(t, dir, newSession(40000, 20), 10)
+	h := provide(t, dir, newSession(60000, 30), 10)
+
+	if len(h.Samples) != 3 {
+		t.Fatalf("expected 3 samples, got %d", len(h.Samples))
+	}
+
+	got := h.ContextPercentages(0)
+	want := []float64{10, 20, 30}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ContextPercentages()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if fh := h.FiveHourUtilization(); len(fh) != 3 || fh[2] != 30 {
+		t.Errorf("FiveHourUtilization() = %v, want [10 20 30]", fh)
+	}
+}
+
+func TestProvider_SkipsUnchangedUsage(t *testing.T) {
+	dir := t.TempDir()
+
+	provide(t, dir, newSession(20000, 10), 10)
+	provide(t, dir, newSession(20000, 10), 10)
+	h := provide(t, dir, newSession(20000, 10), 10)
+
+	if len(h.Samples) != 1 {
+		t.Errorf("expected 1 sample for unchanged usage, got %d", len(h.Samples))
+	}
+}
+
+func TestProvider_KeepsLastSamples(t *testing.T) {
+	dir := t.TempDir()
+
+	var h *History
+	for i := range 5 {
+		h = provide(t, dir, newSession(int64(i+1)*1000, 0), 3)
+	}
+
+	if len(h.Samples) != 3 {
+		t.Fatalf("expected 3 samples, got %d", len(h.Samples))
+	}
+	if h.Samples[0].ContextTokens != 3000 || h.Samples[2].ContextTokens != 5000 {
+		t.Errorf("expected oldest samples to be dropped, got %+v", h.Samples)
+	}
+}
+
+func TestProvider_WithoutCache(t *testing.T) {
+	p := &Provider{session: newSession(1000, 5), samples: 10}
+
+	result, err := p.Provide(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if h := result.(*History); len(h.Samples) != 1 {
+		t.Errorf("expected single sample without cache, got %d", len(h.Samples))
+	}
+}
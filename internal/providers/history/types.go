@@ -0,0 +1,56 @@
+package history
+
+import "time"
+
+// Sample is a snapshot of session usage taken when it changes.
+type Sample struct {
+	At                time.Time `json:"at"`
+	ContextTokens     int64     `json:"context_tokens"`
+	ContextWindowSize int64     `json:"context_window_size"`
+	FiveHour          *float64  `json:"five_hour,omitempty"` // nil when rate limit data is unavailable
+	SevenDay          *float64  `json:"seven_day,omitempty"` // nil when rate limit data is unavailable
+}
+
+// History holds the most recent samples for a session, oldest first.
+type History struct {
+	Samples []Sample `json:"samples"`
+}
+
+// ContextPercentages returns context usage percentages for each sample.
+// fallbackLimit is used for samples recorded without a context window size.
+func (h *History) ContextPercentages(fallbackLimit int64) []float64 {
+	values := make([]float64, 0, len(h.Samples))
+	for _, s := range h.Samples {
+		limit := s.ContextWindowSize
+		if limit == 0 {
+			limit = fallbackLimit
+		}
+		if limit <= 0 {
+			continue
+		}
+		values = append(values, float64(s.ContextTokens)/float64(limit)*100)
+	}
+	return values
+}
+
+// FiveHourUtilization returns the 5-hour rate limit utilization for samples that have it.
+func (h *History) FiveHourUtilization() []float64 {
+	values := make([]float64, 0, len(h.Samples))
+	for _, s := range h.Samples {
+		if s.FiveHour != nil {
+			values = append(values, *s.FiveHour)
+		}
+	}
+	return values
+}
+
+// SevenDayUtilization returns the 7-day rate limit utilization for samples that have it.
+func (h *History) SevenDayUtilization() []float64 {
+	values := make([]float64, 0, len(h.Samples))
+	for _, s := range h.Samples {
+		if s.SevenDay != nil {
+			values = append(values, *s.SevenDay)
+		}
+	}
+	return values
+}
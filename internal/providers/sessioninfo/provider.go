@@ -7,11 +7,14 @@ import (
 	"github.com/mirage20/ccstatus-go/internal/core"
 )
 
+// Schema version of SessionInfo in the cache; bump when SessionInfo changes.
+const schemaVersion = 1
+
 func init() {
 	// Self-register with type factory
 	core.RegisterProvider(string(Key), New, func() interface{} {
 		return &SessionInfo{}
-	})
+	}, schemaVersion)
 }
 
 // Provider provides session information from the Claude session.
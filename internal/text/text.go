@@ -0,0 +1,132 @@
+package text
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// Ellipsis marks where text was truncated.
+const Ellipsis = "…"
+
+// Position selects where Truncate removes text.
+type Position string
+
+// Supported truncation positions.
+const (
+	TruncateStart  Position = "start"  // "…ong-name"
+	TruncateMiddle Position = "middle" // "lo…name"
+	TruncateEnd    Position = "end"    // "long-na…"
+)
+
+// ansiPattern matches ANSI CSI escape sequences (colors, cursor movement).
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// ParsePosition converts a position name to a Position constant.
+// Returns TruncateMiddle as default if the name is not recognized.
+func ParsePosition(name string) Position {
+	switch Position(strings.ToLower(name)) {
+	case TruncateStart:
+		return TruncateStart
+	case TruncateEnd:
+		return TruncateEnd
+	case TruncateMiddle:
+		return TruncateMiddle
+	default:
+		return TruncateMiddle
+	}
+}
+
+// StripANSI removes ANSI escape sequences from s.
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// Width returns the number of terminal columns s occupies.
+// East Asian wide characters and emoji count as two columns; ANSI escapes count as none.
+func Width(s string) int {
+	return uniseg.StringWidth(StripANSI(s))
+}
+
+// Truncate shortens s to at most maxWidth columns, replacing the removed
+// graphemes with an ellipsis at the given position. ANSI escapes are removed
+// from truncated text. Returns s unchanged, colors included, if maxWidth <= 0
+// (no limit) or s already fits.
+// Examples (maxWidth 10):
+//
+//	"feature/long-branch" middle -> "feat…ranch"
+//	"feature/long-branch" start  -> "…ng-branch"
+//	"feature/long-branch" end    -> "feature/l…"
+func Truncate(s string, maxWidth int, pos Position) string {
+	if maxWidth <= 0 {
+		return s
+	}
+
+	plain := StripANSI(s)
+	clusters, widths, total := graphemes(plain)
+	if total <= maxWidth {
+		return s
+	}
+
+	// Reserve one column for the ellipsis
+	avail := maxWidth - Width(Ellipsis)
+	if avail <= 0 {
+		return Ellipsis
+	}
+
+	switch pos {
+	case TruncateStart:
+		return Ellipsis + suffix(clusters, widths, avail)
+	case TruncateEnd:
+		return prefix(clusters, widths, avail) + Ellipsis
+	case TruncateMiddle:
+		fallthrough
+	default:
+		head := prefix(clusters, widths, avail/2) //nolint:mnd // split in half
+		// Give any column a wide grapheme couldn't use to the tail
+		return head + Ellipsis + suffix(clusters, widths, avail-uniseg.StringWidth(head))
+	}
+}
+
+// graphemes splits s into grapheme clusters with their display widths.
+//
+//nolint:nonamedreturns // named returns document the meaning of each value
+func graphemes(s string) (clusters []string, widths []int, total int) {
+	state := -1
+	for s != "" {
+		var cluster string
+		var width int
+		cluster, s, width, state = uniseg.FirstGraphemeClusterInString(s, state)
+		clusters = append(clusters, cluster)
+		widths = append(widths, width)
+		total += width
+	}
+	return clusters, widths, total
+}
+
+// prefix returns the leading graphemes that fit within budget columns.
+func prefix(clusters []string, widths []int, budget int) string {
+	var b strings.Builder
+	for i, cluster := range clusters {
+		if widths[i] > budget {
+			break
+		}
+		budget -= widths[i]
+		b.WriteString(cluster)
+	}
+	return b.String()
+}
+
+// suffix returns the trailing graphemes that fit within budget columns.
+func suffix(clusters []string, widths []int, budget int) string {
+	start := len(clusters)
+	for start > 0 && widths[start-1] <= budget {
+		budget -= widths[start-1]
+		start--
+	}
+	return strings.Join(clusters[start:], "")
+}
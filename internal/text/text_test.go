@@ -0,0 +1,85 @@
+package text
+
+import "testing"
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"main", 4},
+		{"", 0},
+		{"feature/日本語", 14},               // CJK characters are two columns wide
+		{"fix-🐛", 6},                      // Emoji are two columns wide
+		{"\033[32mgreen\033[0m", 5},       // ANSI escapes take no columns
+		{"cafe\u0301", 4},                 // Combining accent joins the previous character
+		{"\U0001F469\u200D\U0001F4BB", 2}, // ZWJ sequence is a single grapheme
+		{"\033[90m\ue725 main\033[0m", 6}, // Nerd Font glyphs are single width
+	}
+
+	for _, tc := range tests {
+		if got := Width(tc.input); got != tc.want {
+			t.Errorf("Width(%q) = %d, want %d", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input    string
+		maxWidth int
+		pos      Position
+		want     string
+	}{
+		// Middle truncation (previous byte-based behavior for ASCII)
+		{"short", 10, TruncateMiddle, "short"},
+		{"feature/long-branch", 10, TruncateMiddle, "feat…ranch"},
+		{"abcdefghij", 5, TruncateMiddle, "ab…ij"},
+		{"abcdefghij", 10, TruncateMiddle, "abcdefghij"},
+
+		// Start and end truncation
+		{"feature/long-branch", 10, TruncateStart, "…ng-branch"},
+		{"feature/long-branch", 10, TruncateEnd, "feature/l…"},
+
+		// No limit and tiny limits
+		{"feature/long-branch", 0, TruncateMiddle, "feature/long-branch"},
+		{"feature/long-branch", 1, TruncateMiddle, "…"},
+
+		// Multibyte names are cut on grapheme boundaries, never mid-rune
+		{"機能/ログイン画面", 9, TruncateMiddle, "機能…画面"},
+		{"機能/ログイン画面", 8, TruncateEnd, "機能/ロ…"},
+		{"機能/ログイン画面", 8, TruncateStart, "…ン画面"},
+		{"fix/🐛🐛🐛🐛-crash", 10, TruncateMiddle, "fix/…crash"},
+		{"café-résumé-naïve", 9, TruncateMiddle, "café…aïve"},
+
+		// ANSI escapes are removed before measuring, and kept if the text fits
+		{"\033[32mabcdefghij\033[0m", 5, TruncateMiddle, "ab…ij"},
+		{"\033[32mabcdefghij\033[0m", 10, TruncateMiddle, "\033[32mabcdefghij\033[0m"},
+	}
+
+	for _, tc := range tests {
+		got := Truncate(tc.input, tc.maxWidth, tc.pos)
+		if got != tc.want {
+			t.Errorf("Truncate(%q, %d, %s) = %q, want %q", tc.input, tc.maxWidth, tc.pos, got, tc.want)
+		}
+		if tc.maxWidth > 0 && Width(got) > tc.maxWidth {
+			t.Errorf("Truncate(%q, %d, %s) width = %d, exceeds limit", tc.input, tc.maxWidth, tc.pos, Width(got))
+		}
+	}
+}
+
+func TestParsePosition(t *testing.T) {
+	tests := map[string]Position{
+		"start":  TruncateStart,
+		"END":    TruncateEnd,
+		"middle": TruncateMiddle,
+		"":       TruncateMiddle,
+		"bogus":  TruncateMiddle,
+	}
+
+	for input, want := range tests {
+		if got := ParsePosition(input); got != want {
+			t.Errorf("ParsePosition(%q) = %q, want %q", input, got, want)
+		}
+	}
+}